@@ -0,0 +1,117 @@
+package homeconfig
+
+import (
+	"reflect"
+)
+
+type group[T any] []Option[T]
+
+func (g group[T]) Apply(cfg *T) {
+	ApplyOptions(cfg, g...)
+}
+
+func (g group[T]) Validate(cfg *T) error {
+	for _, opt := range g {
+		if validator, ok := opt.(Validator[T]); ok {
+			if err := validator.Validate(cfg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Group combines the options into one, they are applied and validated in order.
+func Group[T any](opts ...Option[T]) Option[T] {
+	return group[T](opts)
+}
+
+// If returns the option if cond is true, otherwise an option doing nothing.
+func If[T any](cond bool, opt Option[T]) Option[T] {
+	if cond {
+		return opt
+	}
+
+	return group[T](nil)
+}
+
+type when[T any] struct {
+	opt  Option[T]
+	cond func(cfg *T) bool
+}
+
+func (w when[T]) Apply(cfg *T) {
+	if w.cond(cfg) {
+		w.opt.Apply(cfg)
+	}
+}
+
+func (w when[T]) Validate(cfg *T) error {
+	if validator, ok := w.opt.(Validator[T]); ok && w.cond(cfg) {
+		return validator.Validate(cfg)
+	}
+
+	return nil
+}
+
+// When applies the option only if cond holds for the config at the time it is applied.
+// Its validation runs only if cond holds for the final config.
+func When[T any](cond func(cfg *T) bool, opt Option[T]) Option[T] {
+	return when[T]{opt: opt, cond: cond}
+}
+
+type defaultOption[T any] struct {
+	opt Option[T]
+}
+
+func (d defaultOption[T]) Apply(cfg *T) {
+	target := reflect.ValueOf(cfg).Elem()
+	if target.Kind() != reflect.Struct {
+		if target.IsZero() {
+			d.opt.Apply(cfg)
+		}
+
+		return
+	}
+
+	var defaults T
+
+	d.opt.Apply(&defaults)
+	mergeFields(cfg, &defaults, false)
+}
+
+// mergeFields copies the non-zero top-level fields of src to dst.
+// Unless override is set, only zero-valued fields of dst are changed.
+func mergeFields[T any](dst, src *T, override bool) {
+	target, source := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	if target.Kind() != reflect.Struct {
+		if !source.IsZero() && (override || target.IsZero()) {
+			target.Set(source)
+		}
+
+		return
+	}
+
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Field(i)
+		if field.CanSet() && !source.Field(i).IsZero() && (override || field.IsZero()) {
+			field.Set(source.Field(i))
+		}
+	}
+}
+
+func (d defaultOption[T]) Validate(cfg *T) error {
+	if validator, ok := d.opt.(Validator[T]); ok {
+		return validator.Validate(cfg)
+	}
+
+	return nil
+}
+
+// Default applies the option only to the fields that are still zero-valued, so it never overrides
+// values set by other options regardless of the order.
+// The option is applied to a zero T first and its non-zero top-level fields are copied.
+func Default[T any](opt Option[T]) Option[T] {
+	return defaultOption[T]{opt: opt}
+}
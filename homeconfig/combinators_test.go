@@ -0,0 +1,75 @@
+package homeconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withBaseURL(u string) Option[clientConfig] {
+	return OptionFunc[clientConfig](func(cfg *clientConfig) { cfg.BaseURL = u })
+}
+
+func TestCombinators(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []Option[clientConfig]
+		want clientConfig
+	}{
+		{
+			name: "If",
+			opts: []Option[clientConfig]{If(true, withBurst(1)), If(false, withTimeout(time.Second))},
+			want: clientConfig{Burst: 1},
+		},
+		{
+			name: "When",
+			opts: []Option[clientConfig]{
+				withBurst(2),
+				When(func(cfg *clientConfig) bool { return cfg.Burst > 1 }, withTimeout(time.Second)),
+				When(func(cfg *clientConfig) bool { return cfg.Burst > 5 }, withBaseURL("http://localhost")),
+			},
+			want: clientConfig{Burst: 2, Timeout: time.Second},
+		},
+		{
+			name: "Group",
+			opts: []Option[clientConfig]{Group(withBurst(3), withBaseURL("http://localhost"))},
+			want: clientConfig{Burst: 3, BaseURL: "http://localhost"},
+		},
+		{
+			name: "Default does not override",
+			opts: []Option[clientConfig]{
+				withBurst(3),
+				Default(Group(withBurst(10), withTimeout(time.Second))),
+			},
+			want: clientConfig{Burst: 3, Timeout: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cfg clientConfig
+
+			require.NoError(t, ApplyOptionsValidated(&cfg, tt.opts...))
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
+
+func TestCombinatorsValidation(t *testing.T) {
+	t.Parallel()
+
+	err := ApplyOptionsValidated(&clientConfig{}, Group(withBurst(1), withTimeout(-time.Second)))
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	err = ApplyOptionsValidated(&clientConfig{}, If(false, withTimeout(-time.Second)))
+	require.NoError(t, err)
+
+	err = ApplyOptionsValidated(&clientConfig{}, Default(withTimeout(-time.Second)))
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
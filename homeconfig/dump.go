@@ -0,0 +1,250 @@
+package homeconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces the values of redacted fields.
+const RedactedValue = "[REDACTED]"
+
+// Change is a difference between two configs found by Diff.
+type Change struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// Dump returns the effective config as a map keyed by field names, nested structs become nested maps.
+// Non-empty values of the redacted fields are replaced by RedactedValue; fields are matched
+// case-insensitively by name, e.g. "Password", or by path, e.g. "DB.Password". Map keys are matched
+// as field names and the elements of slices share the path of the slice, e.g. "DBs.Password".
+// Durations are formatted as strings, unexported fields are skipped.
+func Dump[T any](cfg T, redactFields ...string) map[string]any {
+	out, _ := dumpValue(reflect.ValueOf(cfg), "", newRedactor(redactFields)).(map[string]any)
+	if out == nil {
+		out = map[string]any{}
+	}
+
+	return out
+}
+
+// DumpJSON returns Dump of the config encoded as JSON, e.g. for startup logging.
+func DumpJSON[T any](cfg T, redactFields ...string) ([]byte, error) {
+	return json.Marshal(Dump(cfg, redactFields...))
+}
+
+// Diff returns the fields whose values differ between a and b, sorted by their dotted path.
+// Redacted fields are reported as changed without revealing their values.
+func Diff[T any](a, b T, redactFields ...string) []Change {
+	redact := newRedactor(redactFields)
+	left := flatten(dumpValue(reflect.ValueOf(a), "", redactor{}), "")
+	right := flatten(dumpValue(reflect.ValueOf(b), "", redactor{}), "")
+
+	// the changes are found on the plain values but reported with the values redacted inside slices
+	redactedLeft := flatten(dumpValue(reflect.ValueOf(a), "", redact), "")
+	redactedRight := flatten(dumpValue(reflect.ValueOf(b), "", redact), "")
+
+	keys := make(map[string]struct{}, len(left))
+	for k := range left {
+		keys[k] = struct{}{}
+	}
+
+	for k := range right {
+		keys[k] = struct{}{}
+	}
+
+	var changes []Change
+
+	for k := range keys {
+		if reflect.DeepEqual(left[k], right[k]) {
+			continue
+		}
+
+		oldValue, newValue := redactedValue(left, redactedLeft, k), redactedValue(right, redactedRight, k)
+
+		if redact.match(k) {
+			oldValue, newValue = RedactedValue, RedactedValue
+		}
+
+		changes = append(changes, Change{Field: k, Old: oldValue, New: newValue})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes
+}
+
+// redactedValue returns the redacted value of the key, RedactedValue if the key is hidden
+// inside a redacted value, e.g. a field of a redacted struct.
+func redactedValue(plain, redacted map[string]any, key string) any {
+	if v, ok := redacted[key]; ok {
+		return v
+	}
+
+	if _, ok := plain[key]; ok {
+		return RedactedValue
+	}
+
+	return nil
+}
+
+type redactor map[string]struct{}
+
+func newRedactor(fields []string) redactor {
+	r := make(redactor, len(fields))
+	for _, f := range fields {
+		r[strings.ToLower(f)] = struct{}{}
+	}
+
+	return r
+}
+
+func (r redactor) match(path string) bool {
+	path = strings.ToLower(path)
+	if _, ok := r[path]; ok {
+		return true
+	}
+
+	_, ok := r[path[strings.LastIndexByte(path, '.')+1:]]
+
+	return ok
+}
+
+func dumpValue(v reflect.Value, path string, redact redactor) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	switch {
+	case isScalar(v.Type()):
+		return v.Interface()
+	case v.Kind() == reflect.Map:
+		return dumpMap(v, path, redact)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		return dumpSlice(v, path, redact)
+	case v.Kind() != reflect.Struct:
+		return v.Interface()
+	}
+
+	out := make(map[string]any, v.NumField())
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		if redact.match(fieldPath) {
+			if v.Field(i).IsZero() {
+				out[field.Name] = dumpValue(v.Field(i), fieldPath, redact)
+			} else {
+				out[field.Name] = RedactedValue
+			}
+
+			continue
+		}
+
+		out[field.Name] = dumpValue(v.Field(i), fieldPath, redact)
+	}
+
+	return out
+}
+
+// dumpMap dumps the map as a map keyed by the formatted keys, redacting the entries like the struct fields.
+func dumpMap(v reflect.Value, path string, redact redactor) any {
+	if v.IsNil() {
+		return v.Interface()
+	}
+
+	out := make(map[string]any, v.Len())
+
+	for iter := v.MapRange(); iter.Next(); {
+		key := fmt.Sprint(iter.Key().Interface())
+
+		entryPath := key
+		if path != "" {
+			entryPath = path + "." + key
+		}
+
+		if redact.match(entryPath) && !iter.Value().IsZero() {
+			out[key] = RedactedValue
+
+			continue
+		}
+
+		out[key] = dumpValue(iter.Value(), entryPath, redact)
+	}
+
+	return out
+}
+
+// dumpSlice dumps the elements which may hold redacted fields, the elements share the path of the slice.
+// Slices of plain values are returned as is.
+func dumpSlice(v reflect.Value, path string, redact redactor) any {
+	if !mayHoldFields(v.Type().Elem()) || (v.Kind() == reflect.Slice && v.IsNil()) {
+		return v.Interface()
+	}
+
+	out := make([]any, v.Len())
+	for i := range out {
+		out[i] = dumpValue(v.Index(i), path, redact)
+	}
+
+	return out
+}
+
+func mayHoldFields(t reflect.Type) bool {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Struct:
+		return t != durationType && !isScalar(t)
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Pointer, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+func flatten(v any, prefix string) map[string]any {
+	out := map[string]any{}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		out[prefix] = v
+		return out
+	}
+
+	for k, value := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		for fk, fv := range flatten(value, key) {
+			out[fk] = fv
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,132 @@
+package homeconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dumpDBConfig struct {
+	Host     string
+	Password string
+}
+
+type dumpConfig struct {
+	Name    string
+	Timeout time.Duration
+	APIKey  string
+	Token   string
+	DB      dumpDBConfig
+	Tags    []string
+	secret  string
+}
+
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	cfg := dumpConfig{
+		Name:    "billing",
+		Timeout: 5 * time.Second,
+		APIKey:  "key",
+		DB:      dumpDBConfig{Host: "localhost", Password: "pass"},
+		Tags:    []string{"a"},
+		secret:  "hidden",
+	}
+
+	got := Dump(cfg, "apikey", "token", "DB.Password")
+
+	assert.Equal(t, map[string]any{
+		"Name":    "billing",
+		"Timeout": "5s",
+		"APIKey":  RedactedValue,
+		"Token":   "",
+		"DB":      map[string]any{"Host": "localhost", "Password": RedactedValue},
+		"Tags":    []string{"a"},
+	}, got)
+
+	data, err := DumpJSON(&cfg, "Password")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Password":"[REDACTED]"`)
+	assert.Contains(t, string(data), `"APIKey":"key"`)
+	assert.NotContains(t, string(data), "hidden")
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	a := dumpConfig{Name: "billing", Timeout: time.Second, DB: dumpDBConfig{Host: "db1", Password: "old"}}
+	b := a
+	b.Timeout = 2 * time.Second
+	b.DB.Host = "db2"
+	b.DB.Password = "new"
+
+	assert.Equal(t, []Change{
+		{Field: "DB.Host", Old: "db1", New: "db2"},
+		{Field: "DB.Password", Old: RedactedValue, New: RedactedValue},
+		{Field: "Timeout", Old: "1s", New: "2s"},
+	}, Diff(a, b, "password"))
+
+	assert.Empty(t, Diff(a, a))
+}
+
+type dumpCollectionsConfig struct {
+	DBs     []dumpDBConfig
+	Replica [1]*dumpDBConfig
+	Headers map[string]string
+	Nested  map[string]dumpDBConfig
+	Ports   []int
+}
+
+func TestDump_Collections(t *testing.T) {
+	t.Parallel()
+
+	cfg := dumpCollectionsConfig{
+		DBs:     []dumpDBConfig{{Host: "db1", Password: "pass1"}, {Host: "db2"}},
+		Replica: [1]*dumpDBConfig{{Host: "replica", Password: "pass2"}},
+		Headers: map[string]string{"Authorization": "Bearer abc", "Accept": "json"},
+		Nested:  map[string]dumpDBConfig{"main": {Host: "db3", Password: "pass3"}},
+		Ports:   []int{80},
+	}
+
+	got := Dump(cfg, "password", "authorization")
+
+	assert.Equal(t, map[string]any{
+		"DBs": []any{
+			map[string]any{"Host": "db1", "Password": RedactedValue},
+			map[string]any{"Host": "db2", "Password": ""},
+		},
+		"Replica": []any{map[string]any{"Host": "replica", "Password": RedactedValue}},
+		"Headers": map[string]any{"Authorization": RedactedValue, "Accept": "json"},
+		"Nested":  map[string]any{"main": map[string]any{"Host": "db3", "Password": RedactedValue}},
+		"Ports":   []int{80},
+	}, got)
+
+	data, err := DumpJSON(cfg, "password", "authorization")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "pass")
+	assert.NotContains(t, string(data), "Bearer")
+}
+
+func TestDiff_Collections(t *testing.T) {
+	t.Parallel()
+
+	a := dumpCollectionsConfig{
+		DBs:     []dumpDBConfig{{Host: "db1", Password: "old"}},
+		Headers: map[string]string{"Authorization": "old"},
+	}
+	b := dumpCollectionsConfig{
+		DBs:     []dumpDBConfig{{Host: "db1", Password: "new"}},
+		Headers: map[string]string{"Authorization": "new"},
+	}
+
+	assert.Equal(t, []Change{
+		{
+			Field: "DBs",
+			Old:   []any{map[string]any{"Host": "db1", "Password": RedactedValue}},
+			New:   []any{map[string]any{"Host": "db1", "Password": RedactedValue}},
+		},
+		{Field: "Headers.Authorization", Old: RedactedValue, New: RedactedValue},
+	}, Diff(a, b, "password", "authorization"))
+}
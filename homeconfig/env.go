@@ -0,0 +1,221 @@
+package homeconfig
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	envTag        = "env"
+	tagDefault    = "default="
+	tagRequired   = "required"
+	listSeparator = ","
+)
+
+var (
+	ErrRequired        = errors.New("required environment variable is not set")
+	ErrUnsupportedType = errors.New("unsupported field type")
+	ErrNotStruct       = errors.New("config must be a struct")
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Load returns a T populated from environment variables described by `env` struct tags:
+//
+//	Timeout time.Duration `env:"TIMEOUT,default=5s"`
+//	Hosts   []string      `env:"HOSTS,default=a,b"`
+//	Token   string        `env:"TOKEN,required"`
+//
+// Variable names are prefixed with "<prefix>_" if prefix is set. The default value must be the last tag option.
+// Nested structs without a tag are loaded recursively. Supported types are strings, bools, numbers,
+// time.Duration, encoding.TextUnmarshaler implementations and slices of them as comma-separated lists.
+func Load[T any](prefix string) (T, error) {
+	var cfg T
+
+	err := LoadInto(prefix, &cfg)
+
+	return cfg, err
+}
+
+// LoadInto populates the struct pointed to by cfg from environment variables, see Load.
+// Fields without a variable set and without a default keep their values.
+func LoadInto(prefix string, cfg any) error {
+	return loadInto(prefix, cfg, os.LookupEnv)
+}
+
+// LookupFunc returns the value of a variable and whether it is set, os.LookupEnv is the default one.
+type LookupFunc func(key string) (string, bool)
+
+// LoadFrom is like Load, but reads the variables with lookup instead of the process environment,
+// e.g. to test configuration in parallel tests.
+func LoadFrom[T any](prefix string, lookup LookupFunc) (T, error) {
+	var cfg T
+
+	err := loadInto(prefix, &cfg, lookup)
+
+	return cfg, err
+}
+
+func loadInto(prefix string, cfg any, lookup LookupFunc) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: got %T", ErrNotStruct, cfg)
+	}
+
+	return loadStruct(prefix, v.Elem(), lookup)
+}
+
+func loadStruct(prefix string, v reflect.Value, lookup LookupFunc) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(envTag)
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && !isScalar(field.Type) {
+				if err := loadStruct(prefix, v.Field(i), lookup); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		name, def, hasDefault, required := parseTag(tag)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		raw, ok := lookup(name)
+		switch {
+		case ok:
+		case hasDefault:
+			raw = def
+		case required:
+			return fmt.Errorf("%w: %s", ErrRequired, name)
+		default:
+			continue
+		}
+
+		if err := setValue(v.Field(i), raw); err != nil {
+			return fmt.Errorf("invalid %s value %q: %w", name, raw, err)
+		}
+	}
+
+	return nil
+}
+
+func parseTag(tag string) (name, def string, hasDefault, required bool) {
+	name, opts, _ := strings.Cut(tag, ",")
+
+	for opts != "" {
+		if strings.HasPrefix(opts, tagDefault) {
+			return name, strings.TrimPrefix(opts, tagDefault), true, required
+		}
+
+		var opt string
+
+		opt, opts, _ = strings.Cut(opts, ",")
+		if strings.TrimSpace(opt) == tagRequired {
+			required = true
+		}
+	}
+
+	return name, "", false, required
+}
+
+func isScalar(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)) //nolint:forcetypeassert
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		if raw != "" {
+			items = strings.Split(raw, listSeparator)
+		}
+
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(s.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+
+		v.Set(s)
+	case reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		if err := setValue(p.Elem(), raw); err != nil {
+			return err
+		}
+
+		v.Set(p)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package homeconfig
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDBConfig struct {
+	Host string `env:"DB_HOST,default=localhost"`
+	Port int    `env:"DB_PORT,default=5432"`
+}
+
+type testConfig struct {
+	Name     string        `env:"NAME,required"`
+	Debug    bool          `env:"DEBUG"`
+	Timeout  time.Duration `env:"TIMEOUT,default=5s"`
+	Ratio    float64       `env:"RATIO,default=0.5"`
+	Retries  uint8         `env:"RETRIES"`
+	Hosts    []string      `env:"HOSTS,default=a, b"`
+	Ports    []int         `env:"PORTS"`
+	Addr     netip.Addr    `env:"ADDR,default=127.0.0.1"`
+	Limit    *int          `env:"LIMIT"`
+	Ignored  string        `env:"-"`
+	Untagged string
+	DB       testDBConfig
+}
+
+func mapLookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestLoadInto(t *testing.T) {
+	t.Parallel()
+
+	var cfg testConfig
+
+	err := loadInto("APP", &cfg, mapLookup(map[string]string{
+		"APP_NAME":    "billing",
+		"APP_DEBUG":   "true",
+		"APP_RETRIES": "3",
+		"APP_PORTS":   "80,443",
+		"APP_LIMIT":   "10",
+		"APP_DB_PORT": "6432",
+		"APP_IGNORED": "value",
+	}))
+	require.NoError(t, err)
+
+	limit := 10
+
+	assert.Equal(t, testConfig{
+		Name:    "billing",
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Ratio:   0.5,
+		Retries: 3,
+		Hosts:   []string{"a", "b"},
+		Ports:   []int{80, 443},
+		Addr:    netip.MustParseAddr("127.0.0.1"),
+		Limit:   &limit,
+		DB:      testDBConfig{Host: "localhost", Port: 6432},
+	}, cfg)
+}
+
+func TestLoadIntoErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     any
+		env     map[string]string
+		wantErr error
+		errText string
+	}{
+		{
+			name:    "Missing required",
+			cfg:     &testConfig{},
+			env:     map[string]string{},
+			wantErr: ErrRequired,
+		},
+		{
+			name:    "Invalid duration",
+			cfg:     &testConfig{},
+			env:     map[string]string{"NAME": "x", "TIMEOUT": "soon"},
+			errText: `invalid TIMEOUT value "soon"`,
+		},
+		{
+			name:    "Overflow",
+			cfg:     &testConfig{},
+			env:     map[string]string{"NAME": "x", "RETRIES": "300"},
+			errText: `invalid RETRIES value "300"`,
+		},
+		{
+			name:    "Not a pointer",
+			cfg:     testConfig{},
+			wantErr: ErrNotStruct,
+		},
+		{
+			name: "Unsupported type",
+			cfg: &struct {
+				Ch chan int `env:"CH"`
+			}{},
+			env:     map[string]string{"CH": "1"},
+			wantErr: ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := loadInto("", tt.cfg, mapLookup(tt.env))
+			require.Error(t, err)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+
+			if tt.errText != "" {
+				assert.Contains(t, err.Error(), tt.errText)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("SVC_NAME", "billing")
+	t.Setenv("SVC_TIMEOUT", "1m")
+
+	cfg, err := Load[testConfig]("SVC")
+	require.NoError(t, err)
+
+	assert.Equal(t, "billing", cfg.Name)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+}
+
+func TestLoadFrom(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := LoadFrom[testConfig]("SVC", mapLookup(map[string]string{"SVC_NAME": "billing"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "billing", cfg.Name)
+}
@@ -0,0 +1,97 @@
+package homeconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported config file format")
+
+// LoadFile returns a T decoded from the file, the format is chosen by the extension:
+// .json, .yaml, .yml or .toml. Field names are matched using the json, yaml or toml struct tags.
+func LoadFile[T any](path string) (T, error) {
+	var cfg T
+
+	err := LoadFileInto(path, &cfg)
+
+	return cfg, err
+}
+
+// LoadFileInto decodes the file into the value pointed to by cfg, see LoadFile.
+func LoadFileInto(path string, cfg any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	return decodeFile(path, data, cfg)
+}
+
+// decodeFile decodes the data of the file at path into cfg, only the keys present in the data are set.
+func decodeFile(path string, data []byte, cfg any) error {
+	var err error
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+	case ".toml":
+		var meta toml.MetaData
+
+		meta, err = toml.Decode(string(data), cfg)
+		if undecoded := meta.Undecoded(); err == nil && len(undecoded) > 0 {
+			err = fmt.Errorf("unknown fields %v", undecoded)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, ext)
+	}
+
+	if err != nil {
+		return fmt.Errorf("decode config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// FromFile reads the file and returns an option decoding it onto the config, see LoadFile.
+// Only the keys present in the file are set, including zero values such as false or 0,
+// nested structs are merged field by field. The file is validated by decoding it into a zero T.
+// Options merge by their order, so place it after the defaults and before the options that must win:
+//
+//	ApplyOptions(&cfg, defaults, fileOpt, WithTimeout(flagTimeout))
+func FromFile[T any](path string) (Option[T], error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var probe T
+	if err = decodeFile(path, data, &probe); err != nil {
+		return nil, err
+	}
+
+	return OptionFunc[T](func(cfg *T) {
+		// the data was decoded into the same type already
+		_ = decodeFile(path, data, cfg)
+	}), nil
+}
+
+// Overlay returns an option setting the non-zero top-level fields of src, the other fields are kept.
+func Overlay[T any](src T) Option[T] {
+	return OptionFunc[T](func(cfg *T) {
+		mergeFields(cfg, &src, true)
+	})
+}
@@ -0,0 +1,107 @@
+package homeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fileConfig struct {
+	Name  string   `json:"name"  yaml:"name"  toml:"name"`
+	Port  int      `json:"port"  yaml:"port"  toml:"port"`
+	Debug bool     `json:"debug" yaml:"debug" toml:"debug"`
+	Tags  []string `json:"tags"  yaml:"tags"  toml:"tags"`
+	DB    fileDB   `json:"db"    yaml:"db"    toml:"db"`
+}
+
+type fileDB struct {
+	Host string `json:"host" yaml:"host" toml:"host"`
+	Port int    `json:"port" yaml:"port" toml:"port"`
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	want := fileConfig{Name: "billing", Port: 8080, Tags: []string{"a", "b"}}
+
+	for _, name := range []string{"config.json", "config.yaml", "config.toml"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := LoadFile[fileConfig](filepath.Join("testdata", name))
+			require.NoError(t, err)
+			assert.Equal(t, want, cfg)
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	_, err := LoadFile[fileConfig](write("config.ini", "name=x"))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = LoadFile[fileConfig](write("unknown.json", `{"nmae": "x"}`))
+	require.Error(t, err)
+
+	_, err = LoadFile[fileConfig](write("unknown.toml", "nmae = \"x\""))
+	require.Error(t, err)
+
+	_, err = LoadFile[fileConfig](write("invalid.yaml", "port: eighty"))
+	require.Error(t, err)
+
+	_, err = LoadFile[fileConfig](filepath.Join(dir, "missing.toml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFromFile(t *testing.T) {
+	t.Parallel()
+
+	fileOpt, err := FromFile[fileConfig](filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	cfg := fileConfig{Name: "default", Port: 80, Debug: true}
+
+	ApplyOptions(&cfg, fileOpt, OptionFunc[fileConfig](func(cfg *fileConfig) { cfg.Port = 9090 }))
+
+	assert.Equal(t, fileConfig{Name: "billing", Port: 9090, Debug: true, Tags: []string{"a", "b"}}, cfg)
+}
+
+func TestFromFileZeroValuesAndNested(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"config.json": `{"port": 0, "debug": false, "db": {"host": "db.local"}}`,
+		"config.yaml": "port: 0\ndebug: false\ndb:\n  host: db.local\n",
+		"config.toml": "port = 0\ndebug = false\n[db]\nhost = \"db.local\"\n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			fileOpt, err := FromFile[fileConfig](path)
+			require.NoError(t, err)
+
+			cfg := fileConfig{Name: "default", Port: 80, Debug: true, DB: fileDB{Host: "localhost", Port: 5432}}
+			ApplyOptions(&cfg, fileOpt)
+
+			assert.Equal(t, fileConfig{Name: "default", DB: fileDB{Host: "db.local", Port: 5432}}, cfg)
+		})
+	}
+}
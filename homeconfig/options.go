@@ -0,0 +1,96 @@
+package homeconfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Option sets a parameter of the config T.
+type Option[T any] interface {
+	Apply(cfg *T)
+}
+
+// OptionFunc is a function implementing Option.
+type OptionFunc[T any] func(cfg *T)
+
+// Apply implements Option.
+func (fn OptionFunc[T]) Apply(cfg *T) {
+	fn(cfg)
+}
+
+// Validator is implemented by options that check the config once all options are applied,
+// see ApplyOptionsValidated.
+type Validator[T any] interface {
+	Validate(cfg *T) error
+}
+
+type validation[T any] struct {
+	Option[T]
+	validate func(cfg *T) error
+}
+
+func (v validation[T]) Validate(cfg *T) error {
+	if validator, ok := v.Option.(Validator[T]); ok {
+		if err := validator.Validate(cfg); err != nil {
+			return err
+		}
+	}
+
+	return v.validate(cfg)
+}
+
+// Validate returns an option that does not change the config but checks it with fn.
+func Validate[T any](fn func(cfg *T) error) Option[T] {
+	return validation[T]{Option: OptionFunc[T](func(*T) {}), validate: fn}
+}
+
+// WithValidation attaches a check to the option, e.g. to reject a negative timeout set by it.
+func WithValidation[T any](opt Option[T], fn func(cfg *T) error) Option[T] {
+	return validation[T]{Option: opt, validate: fn}
+}
+
+// Required returns an option failing validation if the value returned by get is zero.
+func Required[T any, V comparable](name string, get func(cfg *T) V) Option[T] {
+	return Validate(func(cfg *T) error {
+		var zero V
+		if get(cfg) == zero {
+			return fmt.Errorf("%s is required", name)
+		}
+
+		return nil
+	})
+}
+
+// ApplyOptions applies the options to the config in order.
+func ApplyOptions[T any](cfg *T, opts ...Option[T]) {
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+}
+
+// ApplyOptionsValidated applies the options to the config and then runs the validations of all
+// options implementing Validator. All validation errors are returned joined and wrap ErrInvalidConfig.
+func ApplyOptionsValidated[T any](cfg *T, opts ...Option[T]) error {
+	ApplyOptions(cfg, opts...)
+
+	var errs []error
+
+	for _, opt := range opts {
+		validator, ok := opt.(Validator[T])
+		if !ok {
+			continue
+		}
+
+		if err := validator.Validate(cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+}
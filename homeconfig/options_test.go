@@ -0,0 +1,101 @@
+package homeconfig
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clientConfig struct {
+	BaseURL string
+	Timeout time.Duration
+	Burst   int
+}
+
+func withTimeout(d time.Duration) Option[clientConfig] {
+	return WithValidation(
+		OptionFunc[clientConfig](func(cfg *clientConfig) { cfg.Timeout = d }),
+		func(cfg *clientConfig) error {
+			if cfg.Timeout < 0 {
+				return errors.New("timeout must not be negative")
+			}
+
+			return nil
+		},
+	)
+}
+
+func withBurst(n int) Option[clientConfig] {
+	return OptionFunc[clientConfig](func(cfg *clientConfig) { cfg.Burst = n })
+}
+
+func TestApplyOptionsValidated(t *testing.T) {
+	t.Parallel()
+
+	requireURL := Required("base URL", func(cfg *clientConfig) string { return cfg.BaseURL })
+
+	tests := []struct {
+		name     string
+		opts     []Option[clientConfig]
+		wantErrs []string
+	}{
+		{
+			name: "Valid",
+			opts: []Option[clientConfig]{
+				withTimeout(time.Second),
+				withBurst(1),
+				OptionFunc[clientConfig](func(cfg *clientConfig) { cfg.BaseURL = "http://localhost" }),
+				requireURL,
+			},
+		},
+		{
+			name: "Invalid",
+			opts: []Option[clientConfig]{
+				withTimeout(-time.Second),
+				withBurst(0),
+				Validate(func(cfg *clientConfig) error {
+					if cfg.Burst <= 0 {
+						return errors.New("burst must be positive")
+					}
+
+					return nil
+				}),
+				requireURL,
+			},
+			wantErrs: []string{"timeout must not be negative", "burst must be positive", "base URL is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cfg clientConfig
+
+			err := ApplyOptionsValidated(&cfg, tt.opts...)
+			if len(tt.wantErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidConfig)
+
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestApplyOptions(t *testing.T) {
+	t.Parallel()
+
+	var cfg clientConfig
+
+	ApplyOptions(&cfg, withTimeout(-time.Second), withBurst(5))
+
+	assert.Equal(t, clientConfig{Timeout: -time.Second, Burst: 5}, cfg)
+}
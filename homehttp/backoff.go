@@ -2,6 +2,8 @@ package homehttp
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -32,3 +34,72 @@ func NoBackoff() BackoffStrategyFunc {
 		return 0
 	}
 }
+
+// RetryAfterBackoff waits for the duration from the Retry-After header of the response, capped at max if it is set.
+// The fallback strategy is used if there is no valid header.
+// A Retry-After date is resolved with the clock of the client using the strategy, see WithClock.
+func RetryAfterBackoff(fallback BackoffStrategy) BackoffStrategy {
+	return retryAfterBackoff{fallback: fallback, now: time.Now}
+}
+
+type retryAfterBackoff struct {
+	fallback BackoffStrategy
+	now      func() time.Time
+}
+
+func (b retryAfterBackoff) Backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	wait, ok := retryAfter(resp, b.now())
+	if !ok {
+		return b.fallback.Backoff(min, max, attemptNum, resp)
+	}
+
+	if max > 0 && wait > max {
+		return max
+	}
+
+	return wait
+}
+
+// withClock returns a copy of the strategy resolving Retry-After dates with the clock,
+// so a strategy shared by clients isn't changed.
+func (b retryAfterBackoff) withClock(clock Clock) BackoffStrategy {
+	b.now = clock.Now
+
+	return b
+}
+
+// retryAfter parses the Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// statusBackoff overrides the wait of the fallback strategy for specific response statuses,
+// a Retry-After header of the response takes precedence over both.
+func statusBackoff(fallback BackoffStrategy, override func(status, attemptNum int) (time.Duration, bool)) BackoffStrategy {
+	return RetryAfterBackoff(BackoffStrategyFunc(func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		if resp != nil {
+			if wait, ok := override(resp.StatusCode, attemptNum); ok {
+				return wait
+			}
+		}
+
+		return fallback.Backoff(min, max, attemptNum, resp)
+	}))
+}
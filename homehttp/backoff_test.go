@@ -54,3 +54,116 @@ func TestBackoffStrategies(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryAfterBackoff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name       string
+		retryAfter string
+		max        time.Duration
+		expected   time.Duration
+	}{
+		{name: "Seconds", retryAfter: "3", expected: 3 * time.Second},
+		{name: "Capped at max", retryAfter: "120", max: 10 * time.Second, expected: 10 * time.Second},
+		{name: "Negative seconds", retryAfter: "-1", expected: 0},
+		{name: "Date in the past", retryAfter: now.Add(-time.Hour).UTC().Format(http.TimeFormat), expected: 0},
+		{name: "Invalid value uses fallback", retryAfter: "soon", expected: time.Second},
+		{name: "Missing header uses fallback", expected: time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{Header: http.Header{}}
+			if tc.retryAfter != "" {
+				resp.Header.Set("Retry-After", tc.retryAfter)
+			}
+
+			result := RetryAfterBackoff(ConstantBackoff(time.Second)).Backoff(0, tc.max, 0, resp)
+			if result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{now.Add(5 * time.Second).Format(http.TimeFormat)}}}
+
+	wait, ok := retryAfter(resp, now)
+	if !ok || wait != 5*time.Second {
+		t.Errorf("expected 5s, got %v (ok=%v)", wait, ok)
+	}
+
+	if _, ok := retryAfter(nil, now); ok {
+		t.Error("expected no Retry-After for a nil response")
+	}
+}
+
+func TestStatusBackoff(t *testing.T) {
+	t.Parallel()
+
+	cfg := &clientConfig{Backoff: ConstantBackoff(time.Second)}
+	WithStatusBackoffOverride(map[int]time.Duration{
+		http.StatusBadGateway:      2 * time.Second,
+		http.StatusTooManyRequests: 30 * time.Second,
+	}).apply(cfg)
+
+	strategy := statusBackoff(cfg.Backoff, cfg.StatusBackoff)
+
+	testCases := []struct {
+		name     string
+		resp     *http.Response
+		expected time.Duration
+	}{
+		{name: "Overridden status", resp: &http.Response{StatusCode: http.StatusBadGateway}, expected: 2 * time.Second},
+		{name: "Other status", resp: &http.Response{StatusCode: http.StatusServiceUnavailable}, expected: time.Second},
+		{name: "Transport error", resp: nil, expected: time.Second},
+		{
+			name: "Retry-After takes precedence over the override",
+			resp: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"5"}},
+			},
+			expected: 5 * time.Second,
+		},
+		{
+			name: "Retry-After takes precedence over the strategy",
+			resp: &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": []string{"3"}},
+			},
+			expected: 3 * time.Second,
+		},
+		{
+			name: "Invalid Retry-After is ignored",
+			resp: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"soon"}},
+			},
+			expected: 30 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.resp != nil && tc.resp.Header == nil {
+				tc.resp.Header = http.Header{}
+			}
+
+			result := strategy.Backoff(0, 0, 0, tc.resp)
+			if result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package homehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ProgressFunc is called after each chunk of a request body is sent, total is -1 if the size is unknown.
+type ProgressFunc func(sent, total int64)
+
+// WithBandwidthLimit limits the rate request bodies are sent with, in bytes per second.
+// Responses are not throttled.
+func WithBandwidthLimit(bytesPerSec int64) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.BandwidthLimit = bytesPerSec
+	})
+}
+
+// WithUploadProgress sets the function notified about the progress of sending request bodies.
+func WithUploadProgress(fn ProgressFunc) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.UploadProgress = fn
+	})
+}
+
+// clientUploadThrottle wraps request bodies into a throttledReader.
+func clientUploadThrottle(bytesPerSec int64, progress ProgressFunc, clock Clock) roundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Body == nil || req.Body == http.NoBody {
+				return next.RoundTrip(req)
+			}
+
+			wrap := func(body io.ReadCloser) io.ReadCloser {
+				return &throttledReader{
+					body:        body,
+					ctx:         req.Context(),
+					clock:       clock,
+					progress:    progress,
+					bytesPerSec: bytesPerSec,
+					total:       req.ContentLength,
+				}
+			}
+
+			r := req.Clone(req.Context())
+			r.Body = wrap(req.Body)
+
+			if req.GetBody != nil {
+				r.GetBody = func() (io.ReadCloser, error) {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+
+					return wrap(body), nil
+				}
+			}
+
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// throttledReader delays reads so that on average no more than bytesPerSec bytes are read per second.
+type throttledReader struct {
+	body     io.ReadCloser
+	ctx      context.Context //nolint:containedctx
+	clock    Clock
+	progress ProgressFunc
+	started  time.Time
+
+	bytesPerSec int64
+	total       int64
+	sent        int64
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if r.bytesPerSec > 0 {
+		if r.started.IsZero() {
+			r.started = r.clock.Now()
+		}
+
+		if int64(len(p)) > r.bytesPerSec {
+			p = p[:r.bytesPerSec]
+		}
+	}
+
+	n, err := r.body.Read(p)
+	if n == 0 {
+		return n, err
+	}
+
+	r.sent += int64(n)
+
+	if r.progress != nil {
+		r.progress(r.sent, r.total)
+	}
+
+	if r.bytesPerSec > 0 {
+		expected := time.Duration(float64(r.sent) / float64(r.bytesPerSec) * float64(time.Second))
+		if wait := expected - r.clock.Now().Sub(r.started); wait > 0 {
+			select {
+			case <-r.clock.After(wait):
+			case <-r.ctx.Done():
+				return n, r.ctx.Err()
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (r *throttledReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,105 @@
+package homehttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// advancingClock moves its time forward by each wait and fires it immediately.
+type advancingClock struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+func (c *advancingClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *advancingClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	c.now = c.now.Add(d)
+	c.mutex.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+
+	return ch
+}
+
+func TestWithBandwidthLimit(t *testing.T) {
+	t.Parallel()
+
+	const size = 4000
+
+	var received int
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = len(body)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	var (
+		mutex     sync.Mutex
+		lastSent  int64
+		lastTotal int64
+	)
+
+	start := time.Now()
+	clock := &advancingClock{now: start}
+	client := NewClient(
+		WithClock(clock),
+		WithBandwidthLimit(1000),
+		WithUploadProgress(func(sent, total int64) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			assert.GreaterOrEqual(t, sent, lastSent)
+			lastSent, lastTotal = sent, total
+		}),
+	)
+
+	// the JSON encoded payload is quoted and ends with a newline
+	resp, err := client.DoJSON(context.Background(), http.MethodPost, testServer.URL, strings.Repeat("a", size-3))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, size, received)
+	assert.Equal(t, int64(size), lastSent)
+	assert.Equal(t, int64(size), lastTotal)
+
+	assert.Equal(t, 4*time.Second, clock.Now().Sub(start))
+}
+
+func TestThrottledReader_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := &throttledReader{
+		body:        io.NopCloser(bytes.NewReader(make([]byte, 100))),
+		ctx:         ctx,
+		clock:       realClock{},
+		bytesPerSec: 10,
+	}
+
+	n, err := r.Read(make([]byte, 100))
+	assert.Equal(t, 10, n)
+	require.ErrorIs(t, err, context.Canceled)
+}
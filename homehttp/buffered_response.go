@@ -0,0 +1,58 @@
+package homehttp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+var ErrResponseTooLarge = errors.New("response body exceeds the buffer size")
+
+// bufferedBody is a response body read into memory, closing it rewinds it, so it can be read again.
+type bufferedBody struct {
+	*bytes.Reader
+}
+
+func (b bufferedBody) Close() error {
+	_, err := b.Seek(0, io.SeekStart)
+
+	return err
+}
+
+// WithBufferedResponses reads response bodies of up to maxSize bytes into memory and closes the connection
+// stream right away. The body can be read multiple times, closing it rewinds it, and it does not leak
+// if it is not closed. Larger bodies fail the request with ErrResponseTooLarge.
+func WithBufferedResponses(maxSize int64) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.TransportMiddlewares = append(c.TransportMiddlewares, clientBufferedResponses(maxSize))
+	})
+}
+
+func clientBufferedResponses(maxSize int64) roundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil || resp.Body == nil {
+				return resp, err
+			}
+
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read response body")
+			}
+
+			if int64(len(data)) > maxSize {
+				return nil, errors.Wrapf(ErrResponseTooLarge, "more than %d bytes", maxSize)
+			}
+
+			resp.Body = bufferedBody{Reader: bytes.NewReader(data)}
+			resp.ContentLength = int64(len(data))
+
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package homehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDoWithBufferedResponses(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, strings.Repeat("a", len(r.URL.Path)))
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(WithBufferedResponses(5))
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL+"/abcd", nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "aaaaa", string(body))
+		require.NoError(t, resp.Body.Close())
+	}
+
+	assert.Equal(t, int64(5), resp.ContentLength)
+
+	_, err = client.DoJSON(context.Background(), http.MethodGet, testServer.URL+"/abcde", nil)
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+}
@@ -5,18 +5,26 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+
+	"github.com/vmyroslav/home-lib/homelogger"
 )
 
 const (
-	defaultUserAgent   = "homehttp.Client"
-	defaultTimeout     = 30 * time.Second
-	defaultRetries     = 0
-	defaultBackoffTime = 300 * time.Millisecond
+	defaultUserAgent    = "homehttp.Client"
+	defaultTimeout      = 30 * time.Second
+	defaultRetries      = 1
+	defaultBackoffTime  = 300 * time.Millisecond
+	defaultMaxRetryWait = 30 * time.Second
+
+	safeDefaultsMaxRetries = 3
 
 	respSizeLimit = int64(10 * 1024 * 1024) // 10MB
 )
@@ -28,15 +36,22 @@ type Client struct {
 	baseClient *http.Client
 	logger     *zerolog.Logger
 	retryer    RetryStrategy
+	har        *harRecorder
+	clock      Clock
+	stats      *clientStats
 
 	backoff      BackoffStrategy
 	retryWaitMin time.Duration
 	retryWaitMax time.Duration
 
-	maxRetries int
+	maxRetries     int
+	idempotentOnly bool
 }
 
-// NewClient returns a new Client.
+// NewClient returns a new Client. By default, idempotent requests answered with 408 Request Timeout
+// or 425 Too Early are retried once after the Retry-After wait, see RetryOnRequestTimeout;
+// WithRetryStrategy replaces this policy, without retries unless WithMaxRetries is given as well.
+// Every wait between retries is capped at 30s, see WithMaxRetryWait.
 func NewClient(opts ...ClientOption) *Client {
 	defaultLogger := zerolog.Nop()
 
@@ -45,10 +60,13 @@ func NewClient(opts ...ClientOption) *Client {
 		Timeout: defaultTimeout,
 		Logger:  &defaultLogger,
 
-		Retryer:    NoRetry,
+		Retryer:    RetryOnRequestTimeout,
 		MaxRetries: defaultRetries,
 
-		Backoff: ConstantBackoff(defaultBackoffTime),
+		Backoff:      RetryAfterBackoff(ConstantBackoff(defaultBackoffTime)),
+		MaxRetryWait: defaultMaxRetryWait,
+
+		Clock: realClock{},
 	}
 
 	for _, o := range opts {
@@ -66,57 +84,187 @@ type clientConfig struct { //nolint:govet
 
 	Retryer    RetryStrategy
 	MaxRetries int
+	// MaxRetriesSet is true if MaxRetries is given explicitly, so WithRetryStrategy doesn't reset it
+	MaxRetriesSet bool
 
-	Backoff      BackoffStrategy
-	MinRetryWait time.Duration
-	MaxRetryWait time.Duration
+	Backoff       BackoffStrategy
+	StatusBackoff func(status, attemptNum int) (time.Duration, bool)
+	MinRetryWait  time.Duration
+	MaxRetryWait  time.Duration
+
+	IdempotentOnlyRetries bool
 
 	Logger *zerolog.Logger
+
+	DNSCacheTTL          time.Duration
+	BlockPrivateNetworks bool
+	Proxy                *url.URL
+
+	DisableStaleConnRedial bool
+
+	HAR *harRecorder
+
+	BandwidthLimit     int64
+	UploadProgress     ProgressFunc
+	DecompressionLimit int64
+
+	Shadow            *shadowMirror
+	ShadowRateLimit   float64
+	ShadowCredentials bool
+
+	ETagCache     *ETagCache
+	DebugRecorder *debugRecorder
+
+	Clock Clock
 }
 
 func buildClient(cfg *clientConfig) *Client {
+	stats := newClientStats(cfg.Clock.Now())
+
+	if cfg.DecompressionLimit > 0 {
+		cfg.TransportMiddlewares = append(cfg.TransportMiddlewares, clientDecompressionLimit(cfg.DecompressionLimit, stats))
+	}
+
+	if cfg.BandwidthLimit > 0 || cfg.UploadProgress != nil {
+		cfg.TransportMiddlewares = append(cfg.TransportMiddlewares,
+			clientUploadThrottle(cfg.BandwidthLimit, cfg.UploadProgress, cfg.Clock))
+	}
+
 	cfg.TransportMiddlewares = append(cfg.TransportMiddlewares, clientUserAgent(cfg.AppName))
 
+	// the mirror and the cache see the requests with all headers set
+	if cfg.Shadow != nil {
+		cfg.Shadow.limiter.now = cfg.Clock.Now
+		cfg.Shadow.credentials = cfg.ShadowCredentials
+
+		if cfg.ShadowRateLimit > 0 {
+			cfg.Shadow.limiter.rate = cfg.ShadowRateLimit
+			cfg.Shadow.limiter.tokens = cfg.ShadowRateLimit
+		}
+		cfg.TransportMiddlewares = append(cfg.TransportMiddlewares, cfg.Shadow.middleware)
+	}
+
+	if cfg.ETagCache != nil {
+		cfg.TransportMiddlewares = append(cfg.TransportMiddlewares, cfg.ETagCache.middleware)
+	}
+
+	// the recorder is the innermost, so it records what goes over the wire
+	if cfg.DebugRecorder != nil {
+		cfg.TransportMiddlewares = append(cfg.TransportMiddlewares, cfg.DebugRecorder.middleware)
+	}
+
+	if cfg.StatusBackoff != nil {
+		cfg.Backoff = statusBackoff(cfg.Backoff, cfg.StatusBackoff)
+	}
+
+	if b, ok := cfg.Backoff.(retryAfterBackoff); ok {
+		cfg.Backoff = b.withClock(cfg.Clock)
+	}
+
+	base := transport(cfg)
+	if !cfg.DisableStaleConnRedial {
+		base = redialStaleConn(base)
+	}
+
 	return &Client{
 		baseClient: &http.Client{
 			Timeout:   cfg.Timeout,
-			Transport: chainRoundTrippers(http.DefaultTransport, cfg.TransportMiddlewares...),
+			Transport: chainRoundTrippers(base, cfg.TransportMiddlewares...),
 		},
 		logger:     cfg.Logger,
 		retryer:    cfg.Retryer,
 		backoff:    cfg.Backoff,
 		maxRetries: cfg.MaxRetries,
+		har:        cfg.HAR,
+		clock:      cfg.Clock,
+		stats:      stats,
+
+		retryWaitMin:   cfg.MinRetryWait,
+		retryWaitMax:   cfg.MaxRetryWait,
+		idempotentOnly: cfg.IdempotentOnlyRetries,
+	}
+}
+
+// transport returns the base transport of the client, a copy of http.DefaultTransport if it is customized.
+func transport(cfg *clientConfig) http.RoundTripper {
+	if cfg.DNSCacheTTL <= 0 && !cfg.BlockPrivateNetworks && cfg.Proxy == nil {
+		return http.DefaultTransport
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if cfg.BlockPrivateNetworks {
+		dialer.Control = blockPrivateNetworks
 	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	t.DialContext = dialer.DialContext
+
+	if cfg.Proxy != nil {
+		t.Proxy = http.ProxyURL(cfg.Proxy)
+	}
+
+	if cfg.BlockPrivateNetworks && t.Proxy != nil {
+		t.Proxy = blockPrivateTargets(t.Proxy, net.DefaultResolver.LookupNetIP)
+	}
+
+	if cfg.DNSCacheTTL > 0 {
+		cache := newDNSCache(cfg.DNSCacheTTL, net.DefaultResolver.LookupHost, dialer)
+		cache.now = cfg.Clock.Now
+		t.DialContext = cache.dialContext
+	}
+
+	return t
 }
 
 // DoJSON executes a request.
-func (c *Client) DoJSON(ctx context.Context, method, url string, payload any) (*http.Response, error) { //nolint:cyclop
+func (c *Client) DoJSON(ctx context.Context, method, url string, payload any) (*http.Response, error) {
 	req, err := NewRequestJSON(ctx, method, url, payload)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
 
+	return c.do(ctx, req)
+}
+
+// do executes the request with retries.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) { //nolint:cyclop
 	var (
 		reqBodyBytes []byte
 		resp         *http.Response
 		shouldRetry  bool
 		doErr        error
+		attempts     int
 	)
 
+	c.stats.recordRequest(c.clock.Now())
+
 	if req.Body != nil {
 		reqBodyBytes, _ = io.ReadAll(req.Body)
 	}
 
+	// the middlewares tell the retries from the first attempt by the attempt number in the context
+	var attempt atomic.Int32
+
+	req = req.WithContext(context.WithValue(req.Context(), attemptKey{}, &attempt))
+
 	for i := 0; ; i++ {
+		attempt.Store(int32(i + 1))
+
 		if reqBodyBytes != nil {
 			req.Body = io.NopCloser(bytes.NewBuffer(reqBodyBytes))
 		}
 
 		resp, doErr = c.baseClient.Do(req)
+		attempts++
+		c.stats.recordAttempt(resp, doErr)
+
 		shouldRetry = c.retryer.Classify(req.Context(), resp, doErr)
+		if shouldRetry && c.idempotentOnly && !IsIdempotent(req.Method) {
+			shouldRetry = isNotSentError(doErr)
+		}
 
 		if doErr != nil {
-			c.logger.Debug().Err(err).
+			homelogger.FromContextOr(ctx, c.logger).Debug().Err(doErr).
 				Str("method", req.Method).
 				Str("url", req.URL.String()).
 				Msg("failed to execute request")
@@ -138,17 +286,21 @@ func (c *Client) DoJSON(ctx context.Context, method, url string, payload any) (*
 			c.drainBody(resp.Body)
 		}
 
+		c.stats.retries.Add(1)
+
 		wait := c.backoff.Backoff(c.retryWaitMin, c.retryWaitMax, i, resp)
+		if c.retryWaitMax > 0 && wait > c.retryWaitMax {
+			// not every strategy respects the max, e.g. ConstantBackoff or a Retry-After of a misbehaving server
+			wait = c.retryWaitMax
+		}
 
 		// Wait before retrying
-		timer := time.NewTimer(wait)
 		select {
 		case <-req.Context().Done():
-			timer.Stop()
 			c.baseClient.CloseIdleConnections()
 
 			return nil, req.Context().Err()
-		case <-timer.C:
+		case <-c.clock.After(wait):
 		}
 	}
 
@@ -157,7 +309,17 @@ func (c *Client) DoJSON(ctx context.Context, method, url string, payload any) (*
 	}
 
 	// retry was not successful
-	return nil, ResponseError{Response: resp, Original: doErr}
+	return nil, RequestError{Response: resp, Original: doErr, Attempts: attempts}
+}
+
+// attemptKey holds the *atomic.Int32 number of the current attempt of a request sent by Client.do.
+type attemptKey struct{}
+
+// isRetryAttempt reports whether the request is a retry of a request sent by Client.do.
+func isRetryAttempt(ctx context.Context) bool {
+	attempt, ok := ctx.Value(attemptKey{}).(*atomic.Int32)
+
+	return ok && attempt.Load() > 1
 }
 
 func (c *Client) drainBody(body io.ReadCloser) {
@@ -167,17 +329,55 @@ func (c *Client) drainBody(body io.ReadCloser) {
 	}
 }
 
-type ResponseError struct {
+// RequestError is returned when a request failed: the transport failed, Original holds the cause,
+// or the server responded with an unsuccessful status, Response holds the response.
+// Attempts is the number of attempts made by the client, 0 if the error didn't come from a retry loop.
+type RequestError struct {
 	Response *http.Response
 	Original error
+	Attempts int
 }
 
-func (r ResponseError) Error() string {
+// ResponseError is the former name of RequestError.
+//
+// Deprecated: use RequestError.
+type ResponseError = RequestError
+
+func (r RequestError) Error() string {
+	var msg string
+
 	if r.Response == nil {
-		return r.Original.Error()
+		msg = r.Original.Error()
+	} else {
+		msg = fmt.Sprintf("%v %v: %d",
+			r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode,
+		)
 	}
 
-	return fmt.Sprintf("%v %v: %d",
-		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode,
-	)
+	if r.Attempts > 1 {
+		msg = fmt.Sprintf("%s (after %d attempts)", msg, r.Attempts)
+	}
+
+	return msg
+}
+
+// Unwrap returns the original error, so the cause can be checked with errors.Is.
+func (r RequestError) Unwrap() error {
+	return r.Original
+}
+
+// Is reports whether the request failed with a timeout for ErrTimeout.
+func (r RequestError) Is(target error) bool {
+	if target != ErrTimeout { //nolint:errorlint
+		return false
+	}
+
+	var netErr net.Error
+
+	return errors.Is(r.Original, context.DeadlineExceeded) || (errors.As(r.Original, &netErr) && netErr.Timeout())
+}
+
+// Temporary reports whether the failure is worth retrying, see IsRetryable.
+func (r RequestError) Temporary() bool {
+	return IsRetryable(r)
 }
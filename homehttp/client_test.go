@@ -1,6 +1,7 @@
 package homehttp
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -12,6 +13,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/vmyroslav/home-lib/homelogger"
 )
 
 func TestClientDo(t *testing.T) {
@@ -352,3 +355,22 @@ func TestClientDoWithAuthorizationTokenOption(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
 }
+
+// TestClientDoUsesContextLogger tests that the client logs with the logger stored in the request context.
+func TestClientDoUsesContextLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	testServer.Close()
+
+	ctx := homelogger.ToContext(context.Background(), homelogger.New(homelogger.WithOutput(&buf)))
+	ctx = homelogger.WithContextFields(ctx, "request_id", "42")
+
+	_, err := NewClient().DoJSON(ctx, http.MethodGet, testServer.URL, nil)
+	require.Error(t, err)
+
+	assert.Contains(t, buf.String(), `"request_id":"42"`)
+	assert.Contains(t, buf.String(), "failed to execute request")
+}
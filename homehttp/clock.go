@@ -0,0 +1,24 @@
+package homehttp
+
+import (
+	"time"
+)
+
+// Clock provides the time to the client, e.g. for the waits between retries.
+// It can be replaced in tests, hometests.FakeClock implements it.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// WithClock sets the clock used by the client.
+func WithClock(clock Clock) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.Clock = clock
+	})
+}
@@ -0,0 +1,96 @@
+package homehttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// instantClock fires all waits immediately and records the requested durations.
+type instantClock struct {
+	waits []time.Duration
+	mutex sync.Mutex
+}
+
+func (c *instantClock) Now() time.Time { return time.Now() }
+
+func (c *instantClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	c.waits = append(c.waits, d)
+	c.mutex.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+
+	return ch
+}
+
+func TestClientDoWithClock(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	clock := &instantClock{}
+	client := NewClient(
+		WithClock(clock),
+		WithRetryStrategy(RetryOn500x),
+		WithMaxRetries(3),
+		WithBackoffStrategy(LinearBackoff(time.Hour)),
+		WithMaxRetryWait(0),
+	)
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, []time.Duration{0, time.Hour}, clock.waits)
+}
+
+func TestClientDoClampsWait(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	clock := &instantClock{}
+	client := NewClient(
+		WithClock(clock),
+		WithRetryStrategy(RetryOn500x),
+		WithMaxRetries(3),
+		WithBackoffStrategy(ConstantBackoff(time.Hour)),
+		WithMaxRetryWait(time.Second),
+	)
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, clock.waits)
+}
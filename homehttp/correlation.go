@@ -0,0 +1,64 @@
+package homehttp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// DefaultRequestIDHeader is the header commonly used for request IDs.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+type requestIDCtxKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID, which is sent by the clients
+// configured with WithCorrelationIDHeader.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+
+	return id
+}
+
+// WithCorrelationIDHeader sets the header to the request ID from the request context, see ContextWithRequestID.
+// If there is none, an ID is created by the generator, or a random one if the generator is nil.
+// Requests already having the header are not changed.
+func WithCorrelationIDHeader(header string, generator func() string) ClientOption {
+	if generator == nil {
+		generator = newRequestID
+	}
+
+	return clientOptionFn(func(c *clientConfig) {
+		c.TransportMiddlewares = append(c.TransportMiddlewares, clientCorrelationID(header, generator))
+	})
+}
+
+// clientCorrelationID adds the request ID header to the request.
+func clientCorrelationID(header string, generator func() string) roundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(header) == "" {
+				id := RequestIDFromContext(req.Context())
+				if id == "" {
+					id = generator()
+				}
+
+				req.Header.Set(header, id)
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
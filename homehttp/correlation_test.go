@@ -0,0 +1,68 @@
+package homehttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDoWithCorrelationIDHeader(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan string, 1)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(DefaultRequestIDHeader)
+	}))
+	t.Cleanup(testServer.Close)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		generator func() string
+		want      string
+	}{
+		{
+			name: "From context",
+			ctx:  ContextWithRequestID(context.Background(), "ctx-id"),
+			want: "ctx-id",
+		},
+		{
+			name:      "Generated",
+			ctx:       context.Background(),
+			generator: func() string { return "generated-id" },
+			want:      "generated-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(WithCorrelationIDHeader(DefaultRequestIDHeader, tt.generator))
+
+			resp, err := client.DoJSON(tt.ctx, http.MethodGet, testServer.URL, nil)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.want, <-received)
+		})
+	}
+
+	client := NewClient(WithCorrelationIDHeader(DefaultRequestIDHeader, nil))
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Len(t, <-received, 32)
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+	assert.Equal(t, "id", RequestIDFromContext(ContextWithRequestID(context.Background(), "id")))
+}
@@ -0,0 +1,124 @@
+package homehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// clientStats holds live counters of the client, see Client.DebugHandler.
+type clientStats struct {
+	started  time.Time
+	statuses map[int]uint64
+	requests atomic.Uint64
+	attempts atomic.Uint64
+	retries  atomic.Uint64
+	errors   atomic.Uint64
+
+	// gzip bytes read from the wire and bytes decoded from them, see WithDecompressionLimit
+	decodedIn  atomic.Uint64
+	decodedOut atomic.Uint64
+
+	// requests of the last minute counted per second, see DebugStats.RequestsPerMinute
+	window [60]requestsBucket
+
+	mutex sync.Mutex
+}
+
+type requestsBucket struct {
+	second int64
+	count  uint64
+}
+
+// DebugStats is the snapshot of the client counters served by Client.DebugHandler.
+type DebugStats struct {
+	Started         time.Time         `json:"started"`
+	Statuses        map[string]uint64 `json:"statuses"`
+	Requests        uint64            `json:"requests"`
+	Attempts        uint64            `json:"attempts"`
+	Retries         uint64            `json:"retries"`
+	Errors          uint64            `json:"errors"`
+	DecodedBytesIn  uint64            `json:"decoded_bytes_in"`
+	DecodedBytesOut uint64            `json:"decoded_bytes_out"`
+	// RequestsPerMinute is the number of requests started during the last minute.
+	RequestsPerMinute float64 `json:"requests_per_minute"`
+}
+
+func newClientStats(now time.Time) *clientStats {
+	return &clientStats{started: now, statuses: make(map[int]uint64)}
+}
+
+func (s *clientStats) recordRequest(now time.Time) {
+	s.requests.Add(1)
+
+	second := now.Unix()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bucket := &s.window[second%int64(len(s.window))]
+	if bucket.second != second {
+		*bucket = requestsBucket{second: second}
+	}
+
+	bucket.count++
+}
+
+func (s *clientStats) recordAttempt(resp *http.Response, err error) {
+	s.attempts.Add(1)
+
+	if err != nil {
+		s.errors.Add(1)
+
+		return
+	}
+
+	s.mutex.Lock()
+	s.statuses[resp.StatusCode]++
+	s.mutex.Unlock()
+}
+
+func (s *clientStats) snapshot(now time.Time) DebugStats {
+	stats := DebugStats{
+		Started:  s.started,
+		Statuses: map[string]uint64{},
+		Requests: s.requests.Load(),
+		Attempts: s.attempts.Load(),
+		Retries:  s.retries.Load(),
+		Errors:   s.errors.Load(),
+
+		DecodedBytesIn:  s.decodedIn.Load(),
+		DecodedBytesOut: s.decodedOut.Load(),
+	}
+
+	s.mutex.Lock()
+	for _, bucket := range s.window {
+		if age := now.Unix() - bucket.second; age >= 0 && age < int64(len(s.window)) {
+			stats.RequestsPerMinute += float64(bucket.count)
+		}
+	}
+
+	for status, n := range s.statuses {
+		stats.Statuses[strconv.Itoa(status)] = n
+	}
+	s.mutex.Unlock()
+
+	return stats
+}
+
+// Stats returns a snapshot of the client counters.
+func (c *Client) Stats() DebugStats {
+	return c.stats.snapshot(c.clock.Now())
+}
+
+// DebugHandler returns an http.Handler serving the client counters as JSON, e.g. to be mounted
+// on an internal debug endpoint of a running service.
+func (c *Client) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", defaultContentType)
+		_ = json.NewEncoder(w).Encode(c.Stats())
+	})
+}
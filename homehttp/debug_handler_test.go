@@ -0,0 +1,74 @@
+package homehttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDebugHandler(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(WithRetryStrategy(RetryOn500x), WithMaxRetries(1), WithBackoffStrategy(NoBackoff()))
+
+	for _, path := range []string{"/ok", "/fail"} {
+		resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL+path, nil)
+		if err == nil {
+			require.NoError(t, resp.Body.Close())
+		}
+	}
+
+	_, err := client.DoJSON(context.Background(), http.MethodGet, "http://127.0.0.1:0", nil)
+	require.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	client.DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/http", nil))
+
+	assert.Equal(t, defaultContentType, rec.Header().Get("Content-Type"))
+
+	var stats DebugStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+
+	assert.Equal(t, uint64(3), stats.Requests)
+	assert.Equal(t, uint64(4), stats.Attempts)
+	assert.Equal(t, uint64(1), stats.Retries)
+	assert.Equal(t, uint64(1), stats.Errors)
+	assert.Equal(t, map[string]uint64{"200": 1, "502": 2}, stats.Statuses)
+	assert.Positive(t, stats.RequestsPerMinute)
+}
+
+func TestClientStatsRequestsPerMinute(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1_700_000_000, 0)
+	stats := newClientStats(start)
+
+	for i := range 3 {
+		stats.recordRequest(start.Add(time.Duration(i) * 20 * time.Second))
+	}
+
+	assert.InDelta(t, 3, stats.snapshot(start.Add(50*time.Second)).RequestsPerMinute, 0)
+	assert.InDelta(t, 2, stats.snapshot(start.Add(70*time.Second)).RequestsPerMinute, 0)
+	assert.InDelta(t, 0, stats.snapshot(start.Add(time.Hour)).RequestsPerMinute, 0)
+
+	// a bucket of the same second a minute later starts from zero
+	stats.recordRequest(start.Add(time.Minute))
+	assert.InDelta(t, 3, stats.snapshot(start.Add(time.Minute)).RequestsPerMinute, 0)
+	assert.Equal(t, uint64(4), stats.snapshot(start.Add(time.Minute)).Requests)
+}
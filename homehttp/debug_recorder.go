@@ -0,0 +1,202 @@
+package homehttp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DebugFormat is the format of the transcripts written by the debug recorder.
+type DebugFormat int
+
+const (
+	// DebugFormatDump writes the raw HTTP/1.1 request and response.
+	DebugFormatDump DebugFormat = iota
+	// DebugFormatCurl writes the request as a curl command followed by the response as comments.
+	DebugFormatCurl
+)
+
+// WithDebugRecorder writes full transcripts of the sampled requests and their responses to w,
+// sampleRate is the fraction of recorded requests from 0 to 1. Bodies are buffered in memory,
+// so it is meant for debugging sessions only. The requests are recorded as sent by the transport,
+// with all headers set by the client, the values of the credential headers are redacted as in WithHARRecorder.
+func WithDebugRecorder(w io.Writer, sampleRate float64, format DebugFormat) ClientOption {
+	rec := &debugRecorder{sampleRate: sampleRate, format: format}
+	rec.write = func(transcript []byte) error {
+		rec.mutex.Lock()
+		defer rec.mutex.Unlock()
+
+		_, err := w.Write(transcript)
+
+		return err
+	}
+
+	return clientOptionFn(func(c *clientConfig) {
+		c.DebugRecorder = rec
+	})
+}
+
+// WithDebugRecorderDir works as WithDebugRecorder but writes each transcript to a separate file in dir.
+func WithDebugRecorderDir(dir string, sampleRate float64, format DebugFormat) ClientOption {
+	rec := &debugRecorder{sampleRate: sampleRate, format: format}
+	rec.write = func(transcript []byte) error {
+		ext := ".txt"
+		if format == DebugFormatCurl {
+			ext = ".sh"
+		}
+
+		name := fmt.Sprintf("%s-%06d%s", time.Now().UTC().Format("20060102T150405.000"), rec.seq.Add(1), ext)
+
+		return os.WriteFile(filepath.Join(dir, name), transcript, 0o600)
+	}
+
+	return clientOptionFn(func(c *clientConfig) {
+		c.DebugRecorder = rec
+	})
+}
+
+type debugRecorder struct {
+	write      func(transcript []byte) error
+	seq        atomic.Uint64
+	sampleRate float64
+	format     DebugFormat
+
+	mutex sync.Mutex
+}
+
+func (r *debugRecorder) middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if r.sampleRate <= 0 || rand.Float64() >= r.sampleRate { //nolint:gosec
+			return next.RoundTrip(req)
+		}
+
+		reqBody, err := readAndRestoreBody(&req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		dumpReq := req.Clone(req.Context())
+		dumpReq.Header = redactedHeader(req.Header)
+		dumpReq.Body = io.NopCloser(bytes.NewReader(reqBody))
+
+		var buf bytes.Buffer
+
+		if r.format == DebugFormatCurl {
+			writeCurl(&buf, dumpReq, reqBody)
+		} else {
+			dump, _ := httputil.DumpRequestOut(dumpReq, true)
+			buf.Write(dump)
+			buf.WriteString("\n\n")
+		}
+
+		start := time.Now()
+		resp, rtErr := next.RoundTrip(req)
+
+		r.writeResponse(&buf, resp, rtErr, time.Since(start))
+
+		// the transcript is best effort and must not fail the request
+		_ = r.write(buf.Bytes())
+
+		return resp, rtErr
+	})
+}
+
+func (r *debugRecorder) writeResponse(buf *bytes.Buffer, resp *http.Response, rtErr error, elapsed time.Duration) {
+	var dump []byte
+
+	if rtErr != nil {
+		dump = []byte("error: " + rtErr.Error())
+	} else {
+		dumpResp := *resp
+		dumpResp.Header = redactedHeader(resp.Header)
+		dump, _ = httputil.DumpResponse(&dumpResp, true)
+
+		// the dump reads the body and replaces it with a copy
+		resp.Body = dumpResp.Body
+	}
+
+	dump = append(dump, fmt.Sprintf("\n(took %s)", elapsed)...)
+
+	if r.format != DebugFormatCurl {
+		buf.Write(dump)
+		buf.WriteString("\n\n")
+
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(string(dump), "\r\n"), "\n") {
+		buf.WriteString("# < " + strings.TrimRight(line, "\r") + "\n")
+	}
+
+	buf.WriteString("\n")
+}
+
+// readAndRestoreBody reads the body and replaces it with a copy, so it can be read again.
+func readAndRestoreBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+
+	return data, nil
+}
+
+// redactedHeader returns a copy of the header with the values of the headers redacted by the HAR recorder replaced.
+func redactedHeader(h http.Header) http.Header {
+	redacted := h.Clone()
+
+	for _, name := range defaultHARRedactedHeaders {
+		for i := range redacted[name] {
+			redacted[name][i] = harRedactedValue
+		}
+	}
+
+	return redacted
+}
+
+func writeCurl(buf *bytes.Buffer, req *http.Request, body []byte) {
+	fmt.Fprintf(buf, "curl -X %s %s", req.Method, shellQuote(req.URL.String()))
+
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range req.Header[k] {
+			fmt.Fprintf(buf, " \\\n  -H %s", shellQuote(k+": "+v))
+		}
+	}
+
+	if len(body) > 0 {
+		fmt.Fprintf(buf, " \\\n  --data-binary %s", shellQuote(string(body)))
+	}
+
+	buf.WriteString("\n")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,148 @@
+package homehttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type syncBuffer struct {
+	buf   bytes.Buffer
+	mutex sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buf.String()
+}
+
+func newDebugTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Echo", "yes")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write(append([]byte("echo:"), body...))
+	}))
+	t.Cleanup(testServer.Close)
+
+	return testServer
+}
+
+func TestWithDebugRecorder(t *testing.T) {
+	t.Parallel()
+
+	testServer := newDebugTestServer(t)
+
+	tests := []struct {
+		name   string
+		format DebugFormat
+		want   []string
+	}{
+		{
+			name:   "Dump",
+			format: DebugFormatDump,
+			want: []string{
+				"POST / HTTP/1.1", `{"name":"it's"}`, "HTTP/1.1 200 OK", "X-Echo: yes", `echo:{"name":"it's"}`,
+				"User-Agent: " + defaultUserAgent, "Authorization: " + harRedactedValue, "Set-Cookie: " + harRedactedValue,
+			},
+		},
+		{
+			name:   "Curl",
+			format: DebugFormatCurl,
+			want: []string{
+				"curl -X POST '" + testServer.URL + "'",
+				`-H 'Content-Type: application/json'`,
+				`--data-binary '{"name":"it'\''s"}`,
+				"# < HTTP/1.1 200 OK",
+				"# < X-Echo: yes",
+				"-H 'User-Agent: " + defaultUserAgent + "'",
+				"-H 'Authorization: " + harRedactedValue + "'",
+				"# < Set-Cookie: " + harRedactedValue,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out syncBuffer
+
+			client := NewClient(WithDebugRecorder(&out, 1, tt.format), WithBasicAuth("user", "secret"))
+
+			resp, err := client.DoJSON(context.Background(), http.MethodPost, testServer.URL, map[string]string{"name": "it's"})
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "echo:{\"name\":\"it's\"}\n", string(body), "response body must stay readable")
+
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+
+			assert.NotContains(t, out.String(), "secret")
+		})
+	}
+}
+
+func TestWithDebugRecorderSampling(t *testing.T) {
+	t.Parallel()
+
+	testServer := newDebugTestServer(t)
+
+	var out syncBuffer
+
+	client := NewClient(WithDebugRecorder(&out, 0, DebugFormatDump))
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Empty(t, out.String())
+}
+
+func TestWithDebugRecorderDir(t *testing.T) {
+	t.Parallel()
+
+	testServer := newDebugTestServer(t)
+	dir := t.TempDir()
+	client := NewClient(WithDebugRecorderDir(dir, 1, DebugFormatCurl))
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.sh"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "curl -X GET")
+}
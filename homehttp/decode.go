@@ -0,0 +1,92 @@
+package homehttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// decodeSnippetSize is the number of body bytes kept in DecodeError.
+const decodeSnippetSize = 256
+
+// DecodeError is returned when a response body is not a valid JSON of the expected type.
+type DecodeError struct {
+	URL        string
+	Snippet    string
+	Err        error
+	StatusCode int
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode response of %s (%d): %v: %q", e.URL, e.StatusCode, e.Err, e.Snippet)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeJSON reads the response body as JSON into T and closes it.
+// A malformed payload results in a *DecodeError.
+func DecodeJSON[T any](resp *http.Response) (T, error) {
+	var v T
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, respSizeLimit))
+	if err != nil {
+		return v, errors.Wrap(err, "failed to read response body")
+	}
+
+	if err = json.Unmarshal(body, &v); err != nil {
+		var u string
+		if resp.Request != nil {
+			u = resp.Request.URL.String()
+		}
+
+		snippet := body
+		if len(snippet) > decodeSnippetSize {
+			snippet = snippet[:decodeSnippetSize]
+		}
+
+		return v, &DecodeError{URL: u, Snippet: string(snippet), Err: err, StatusCode: resp.StatusCode}
+	}
+
+	return v, nil
+}
+
+// DoJSONAs executes the request with DoJSON and decodes a successful (2xx) response into T.
+// Transport failures and non-2xx statuses are returned as RequestError, the latter with
+// the buffered body; malformed payloads as *DecodeError.
+func DoJSONAs[T any](ctx context.Context, c *Client, method, url string, payload any) (T, error) {
+	var v T
+
+	resp, err := c.DoJSON(ctx, method, url, payload)
+	if err != nil {
+		return v, err
+	}
+
+	if !isSuccess(resp) {
+		return v, bufferedRequestError(resp)
+	}
+
+	return DecodeJSON[T](resp)
+}
+
+func isSuccess(resp *http.Response) bool {
+	return resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
+}
+
+// bufferedRequestError closes the response body and returns a RequestError whose body is
+// still readable.
+func bufferedRequestError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, respSizeLimit))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return RequestError{Response: resp}
+}
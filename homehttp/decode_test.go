@@ -0,0 +1,63 @@
+package homehttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoJSONAs(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = io.WriteString(w, `{"id":1,"name":"widget"}`)
+		case "/malformed":
+			_, _ = io.WriteString(w, `{"id":"one"`+strings.Repeat(" ", 300)+`}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":"not found"}`)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient()
+
+	got, err := DoJSONAs[item](context.Background(), client, http.MethodGet, testServer.URL+"/ok", nil)
+	require.NoError(t, err)
+	assert.Equal(t, item{ID: 1, Name: "widget"}, got)
+
+	_, err = DoJSONAs[item](context.Background(), client, http.MethodGet, testServer.URL+"/malformed", nil)
+
+	var decodeErr *DecodeError
+
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, http.StatusOK, decodeErr.StatusCode)
+	assert.Equal(t, testServer.URL+"/malformed", decodeErr.URL)
+	assert.Len(t, decodeErr.Snippet, decodeSnippetSize)
+
+	_, err = DoJSONAs[item](context.Background(), client, http.MethodGet, testServer.URL+"/missing", nil)
+
+	var respErr ResponseError
+
+	require.ErrorAs(t, err, &respErr)
+	require.False(t, errors.As(err, &decodeErr))
+	assert.Equal(t, http.StatusNotFound, respErr.Response.StatusCode)
+
+	body, err := io.ReadAll(respErr.Response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"error":"not found"}`, string(body))
+}
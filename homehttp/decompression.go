@@ -0,0 +1,108 @@
+package homehttp
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+var ErrDecompressedSizeExceeded = errors.New("decompressed response body exceeds the size limit")
+
+// WithDecompressionLimit makes the client decode gzip responses itself and fail reading a body
+// with ErrDecompressedSizeExceeded once more than maxBytes were decompressed, protecting from zip bombs.
+// Requests with an explicit Accept-Encoding header are not affected, their bodies are returned as is.
+func WithDecompressionLimit(maxBytes int64) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.DecompressionLimit = maxBytes
+	})
+}
+
+// clientDecompressionLimit requests gzip explicitly, which disables the transparent decompression
+// of http.Transport, and decodes the body with the size limit. The decoded bytes are counted in stats.
+func clientDecompressionLimit(maxBytes int64, stats *clientStats) roundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Accept-Encoding") != "" || req.Method == http.MethodHead {
+				return next.RoundTrip(req)
+			}
+
+			// the client retries the same request, it must not look like one with an explicit encoding
+			req = req.Clone(req.Context())
+			req.Header.Set("Accept-Encoding", "gzip")
+
+			resp, err := next.RoundTrip(req)
+			if err != nil || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+				return resp, err
+			}
+
+			resp.Body = &limitedGzipBody{body: resp.Body, stats: stats, remaining: maxBytes}
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = -1
+			resp.Uncompressed = true
+
+			return resp, nil
+		})
+	}
+}
+
+type limitedGzipBody struct {
+	body      io.ReadCloser
+	zr        *gzip.Reader
+	stats     *clientStats
+	err       error
+	remaining int64
+}
+
+func (b *limitedGzipBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if b.zr == nil {
+		if b.zr, b.err = gzip.NewReader(&countingReader{r: b.body, n: &b.stats.decodedIn}); b.err != nil {
+			return 0, b.err
+		}
+	}
+
+	// read one byte over the limit to tell a body of exactly maxBytes from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.zr.Read(p)
+	b.remaining -= int64(n)
+
+	if b.remaining < 0 {
+		b.err = ErrDecompressedSizeExceeded
+		n += int(b.remaining)
+		b.stats.decodedOut.Add(uint64(n))
+
+		return n, b.err
+	}
+
+	b.stats.decodedOut.Add(uint64(n))
+
+	return n, err
+}
+
+func (b *limitedGzipBody) Close() error {
+	return b.body.Close()
+}
+
+// countingReader adds the number of read bytes to n.
+type countingReader struct {
+	r io.Reader
+	n *atomic.Uint64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(uint64(n))
+
+	return n, err
+}
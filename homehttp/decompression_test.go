@@ -0,0 +1,154 @@
+package homehttp
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDoWithDecompressionLimit(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := strings.Repeat("a", 1000)
+
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			_, _ = io.WriteString(w, body)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+
+		zw := gzip.NewWriter(w)
+		_, _ = io.WriteString(zw, body)
+		_ = zw.Close()
+	}))
+	t.Cleanup(testServer.Close)
+
+	tests := []struct {
+		name     string
+		limit    int64
+		wantErr  error
+		wantSize int
+	}{
+		{name: "Below limit", limit: 2000, wantSize: 1000},
+		{name: "Exactly at limit", limit: 1000, wantSize: 1000},
+		{name: "Above limit", limit: 999, wantErr: ErrDecompressedSizeExceeded, wantSize: 999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := NewClient(WithDecompressionLimit(tt.limit))
+
+			resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Len(t, body, tt.wantSize)
+			assert.True(t, resp.Uncompressed)
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		})
+	}
+}
+
+func TestClientDoWithDecompressionLimitExplicitEncoding(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "identity", r.Header.Get("Accept-Encoding"))
+		_, _ = io.WriteString(w, "plain")
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(WithHeader("Accept-Encoding", "identity"), WithDecompressionLimit(1))
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(body))
+}
+
+func TestClientDoWithDecompressionLimitRetry(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Encoding", "gzip")
+
+		zw := gzip.NewWriter(w)
+		_, _ = io.WriteString(zw, strings.Repeat("a", 1000))
+		_ = zw.Close()
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(
+		WithRetryStrategy(RetryOn500x),
+		WithMaxRetries(1),
+		WithBackoffStrategy(NoBackoff()),
+		WithDecompressionLimit(2000),
+	)
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, strings.Repeat("a", 1000), string(body))
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	assert.Equal(t, int32(2), calls.Load())
+
+	stats := client.Stats()
+	assert.Equal(t, uint64(1000), stats.DecodedBytesOut)
+	assert.Positive(t, stats.DecodedBytesIn)
+	assert.Less(t, stats.DecodedBytesIn, stats.DecodedBytesOut)
+}
+
+func TestClientDoWithDecompressionLimitOverridesSafeDefaults(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+
+		zw := gzip.NewWriter(w)
+		_, _ = io.WriteString(zw, strings.Repeat("a", 1000))
+		_ = zw.Close()
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(SafeDefaults(), WithDecompressionLimit(10))
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.ErrorIs(t, err, ErrDecompressedSizeExceeded)
+	assert.Len(t, body, 10)
+}
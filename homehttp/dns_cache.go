@@ -0,0 +1,132 @@
+package homehttp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxNegativeDNSCacheTTL limits how long failed lookups are cached.
+const maxNegativeDNSCacheTTL = 5 * time.Second
+
+// WithDNSCache caches host lookups of the client for ttl, failed lookups are cached for a shorter time.
+// Expired entries are still used while they are refreshed in the background.
+func WithDNSCache(ttl time.Duration) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.DNSCacheTTL = ttl
+	})
+}
+
+type dnsCache struct {
+	entries     map[string]*dnsEntry
+	lookup      func(ctx context.Context, host string) ([]string, error)
+	dialer      *net.Dialer
+	now         func() time.Time
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mutex sync.Mutex
+}
+
+type dnsEntry struct {
+	expires    time.Time
+	err        error
+	addrs      []string
+	refreshing bool
+}
+
+func newDNSCache(
+	ttl time.Duration,
+	lookup func(ctx context.Context, host string) ([]string, error),
+	dialer *net.Dialer,
+) *dnsCache {
+	return &dnsCache{
+		entries:     make(map[string]*dnsEntry),
+		lookup:      lookup,
+		dialer:      dialer,
+		now:         time.Now,
+		ttl:         ttl,
+		negativeTTL: min(ttl, maxNegativeDNSCacheTTL),
+	}
+}
+
+func (c *dnsCache) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if net.ParseIP(host) != nil {
+		return c.dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := c.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+
+	for _, ip := range addrs {
+		if conn, err = c.dialer.DialContext(ctx, network, net.JoinHostPort(ip, port)); err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
+
+func (c *dnsCache) resolve(ctx context.Context, host string) ([]string, error) {
+	c.mutex.Lock()
+
+	entry, ok := c.entries[host]
+	switch {
+	case ok && c.now().Before(entry.expires):
+		c.mutex.Unlock()
+
+		return entry.addrs, entry.err
+	case ok && entry.err == nil:
+		// serve the stale addresses while refreshing them
+		if !entry.refreshing {
+			entry.refreshing = true
+
+			go c.refresh(context.WithoutCancel(ctx), host)
+		}
+
+		c.mutex.Unlock()
+
+		return entry.addrs, nil
+	}
+
+	c.mutex.Unlock()
+
+	return c.refresh(ctx, host)
+}
+
+func (c *dnsCache) refresh(ctx context.Context, host string) ([]string, error) {
+	addrs, err := c.lookup(ctx, host)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil {
+		// keep serving the previous addresses if the refresh of a known host failed
+		if entry, ok := c.entries[host]; ok && entry.err == nil && len(entry.addrs) > 0 {
+			entry.refreshing = false
+			entry.expires = c.now().Add(c.negativeTTL)
+
+			return entry.addrs, nil
+		}
+
+		c.entries[host] = &dnsEntry{err: err, expires: c.now().Add(c.negativeTTL)}
+
+		return nil, err
+	}
+
+	c.entries[host] = &dnsEntry{addrs: addrs, expires: c.now().Add(c.ttl)}
+
+	return addrs, nil
+}
@@ -0,0 +1,109 @@
+package homehttp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSCacheResolve(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls   atomic.Int32
+		fail    atomic.Bool
+		now     = time.Now()
+		nowLock sync.Mutex
+	)
+
+	cache := newDNSCache(time.Minute, func(_ context.Context, host string) ([]string, error) {
+		calls.Add(1)
+
+		if fail.Load() {
+			return nil, errors.New("lookup failed")
+		}
+
+		return []string{"10.0.0.1"}, nil
+	}, &net.Dialer{})
+	cache.now = func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+
+		return now
+	}
+	advance := func(d time.Duration) {
+		nowLock.Lock()
+		now = now.Add(d)
+		nowLock.Unlock()
+	}
+
+	addrs, err := cache.resolve(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	_, _ = cache.resolve(context.Background(), "example.com")
+	assert.Equal(t, int32(1), calls.Load(), "fresh entry must be served from the cache")
+
+	advance(2 * time.Minute)
+
+	addrs, err = cache.resolve(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs, "stale entry must be served while refreshing")
+	assert.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+
+	fail.Store(true)
+
+	_, err = cache.resolve(context.Background(), "unknown.example.com")
+	require.Error(t, err)
+
+	_, err = cache.resolve(context.Background(), "unknown.example.com")
+	require.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load(), "failed lookup must be cached")
+
+	advance(maxNegativeDNSCacheTTL + time.Second)
+
+	_, err = cache.resolve(context.Background(), "unknown.example.com")
+	require.Error(t, err)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestClientDoWithDNSCache(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(testServer.Close)
+
+	u, err := url.Parse(testServer.URL)
+	require.NoError(t, err)
+
+	var lookups atomic.Int32
+
+	cache := newDNSCache(time.Minute, func(context.Context, string) ([]string, error) {
+		lookups.Add(1)
+
+		return []string{u.Hostname()}, nil
+	}, &net.Dialer{})
+	client := NewClient()
+	client.baseClient.Transport = &http.Transport{DialContext: cache.dialContext}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.DoJSON(context.Background(), http.MethodGet, "http://service.test:"+u.Port(), nil)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+
+	assert.Equal(t, int32(1), lookups.Load())
+}
@@ -0,0 +1,85 @@
+package homehttp
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmyroslav/home-lib/homeconfig"
+)
+
+type envConfig struct {
+	AppName      string        `env:"HTTP_APP_NAME"`
+	Proxy        string        `env:"HTTP_PROXY_URL"`
+	Timeout      time.Duration `env:"HTTP_TIMEOUT"`
+	Backoff      time.Duration `env:"HTTP_BACKOFF"`
+	MaxRetryWait time.Duration `env:"HTTP_MAX_RETRY_WAIT"`
+	MaxRetries   *int          `env:"HTTP_MAX_RETRIES"`
+}
+
+// NewClientFromEnv returns a Client configured from environment variables, each name is prefixed
+// with "<prefix>_" if prefix is set:
+//
+//	HTTP_APP_NAME        user agent
+//	HTTP_TIMEOUT         request timeout, e.g. 10s
+//	HTTP_MAX_RETRIES     retries on 5xx, 408 and 425 responses, 0 disables the retries
+//	                     (default 1 retry on 408 and 425 responses)
+//	HTTP_BACKOFF         constant wait between retries, Retry-After is honored
+//	HTTP_MAX_RETRY_WAIT  cap of the wait between retries (default 30s)
+//	HTTP_PROXY_URL       proxy for all requests instead of the HTTP_PROXY/HTTPS_PROXY variables
+//
+// The options are applied after the environment configuration, so they take precedence.
+func NewClientFromEnv(prefix string, opts ...ClientOption) (*Client, error) {
+	cfg, err := homeconfig.Load[envConfig](prefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client config")
+	}
+
+	envOpts, err := cfg.options()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(append(envOpts, opts...)...), nil
+}
+
+func (cfg envConfig) options() ([]ClientOption, error) {
+	var opts []ClientOption
+
+	if cfg.AppName != "" {
+		opts = append(opts, WithAppName(cfg.AppName))
+	}
+
+	if cfg.Timeout > 0 {
+		opts = append(opts, WithTimeout(cfg.Timeout))
+	}
+
+	// an explicit 0 disables the default retries, so only an unset variable keeps them
+	if cfg.MaxRetries != nil {
+		if *cfg.MaxRetries > 0 {
+			opts = append(opts, WithRetryStrategy(MultiRetryStrategies{RetryOn500x, RetryOnRequestTimeout}))
+		}
+
+		opts = append(opts, WithMaxRetries(*cfg.MaxRetries))
+	}
+
+	if cfg.Backoff > 0 {
+		opts = append(opts, WithBackoffStrategy(RetryAfterBackoff(ConstantBackoff(cfg.Backoff))))
+	}
+
+	if cfg.MaxRetryWait > 0 {
+		opts = append(opts, WithMaxRetryWait(cfg.MaxRetryWait))
+	}
+
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, errors.Errorf("invalid HTTP_PROXY_URL value %q", cfg.Proxy)
+		}
+
+		opts = append(opts, WithProxy(proxy))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,93 @@
+package homehttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	var calls atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "billing", r.Header.Get("User-Agent"))
+
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	t.Setenv("SVC_HTTP_APP_NAME", "billing")
+	t.Setenv("SVC_HTTP_TIMEOUT", "5s")
+	t.Setenv("SVC_HTTP_MAX_RETRIES", "2")
+	t.Setenv("SVC_HTTP_BACKOFF", "1ms")
+	t.Setenv("SVC_HTTP_MAX_RETRY_WAIT", "1s")
+
+	client, err := NewClientFromEnv("SVC")
+	require.NoError(t, err)
+
+	assert.Equal(t, 5*time.Second, client.baseClient.Timeout)
+	assert.Equal(t, 2, client.maxRetries)
+	assert.Equal(t, time.Second, client.retryWaitMax)
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestNewClientFromEnvErrors(t *testing.T) {
+	t.Setenv("BAD_HTTP_TIMEOUT", "soon")
+
+	_, err := NewClientFromEnv("BAD")
+	require.Error(t, err)
+
+	t.Setenv("PROXY_HTTP_PROXY_URL", "not a url")
+
+	_, err = NewClientFromEnv("PROXY")
+	require.Error(t, err)
+}
+
+func TestNewClientFromEnvMaxRetries(t *testing.T) {
+	client, err := NewClientFromEnv("UNSET")
+	require.NoError(t, err)
+	assert.Equal(t, defaultRetries, client.maxRetries)
+
+	t.Setenv("NORETRY_HTTP_MAX_RETRIES", "0")
+
+	client, err = NewClientFromEnv("NORETRY")
+	require.NoError(t, err)
+	assert.Equal(t, 0, client.maxRetries)
+}
+
+func TestNewClientFromEnvProxy(t *testing.T) {
+	proxied := make(chan string, 1)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		proxied <- r.URL.String()
+	}))
+	t.Cleanup(proxy.Close)
+
+	t.Setenv("HTTP_PROXY_URL", proxy.URL)
+
+	client, err := NewClientFromEnv("", WithTimeout(time.Second))
+	require.NoError(t, err)
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, "http://service.test/path", nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, "http://service.test/path", <-proxied)
+}
@@ -0,0 +1,59 @@
+package homehttp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// StatusCode returns the status code of the response carried by a RequestError in the chain of err,
+// or 0 if there is no response, e.g. the request failed before it.
+func StatusCode(err error) int {
+	var respErr RequestError
+	if errors.As(err, &respErr) && respErr.Response != nil {
+		return respErr.Response.StatusCode
+	}
+
+	var respErrPtr *RequestError
+	if errors.As(err, &respErrPtr) && respErrPtr != nil && respErrPtr.Response != nil {
+		return respErrPtr.Response.StatusCode
+	}
+
+	return 0
+}
+
+// IsTimeout reports whether the request failed because of a timeout of the client, the context or the network,
+// including a wrapped *url.Error.
+func IsTimeout(err error) bool {
+	if errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsRateLimited reports whether the server rejected the request with 429 Too Many Requests.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsRetryable reports whether the request failed with an error worth retrying: a timeout, a refused or
+// dropped connection, a rate limit, 408 Request Timeout, 425 Too Early or a 5xx status.
+// A canceled context is never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if IsTimeout(err) || isNotSentError(err) || isStaleConnError(err) {
+		return true
+	}
+
+	status := StatusCode(err)
+
+	return status >= http.StatusInternalServerError || status == http.StatusTooManyRequests ||
+		status == http.StatusRequestTimeout || status == http.StatusTooEarly
+}
@@ -0,0 +1,116 @@
+package homehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHelpers(t *testing.T) {
+	t.Parallel()
+
+	statusErr := func(status int) error {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+
+		return ResponseError{Response: &http.Response{StatusCode: status, Request: req}}
+	}
+
+	tests := []struct {
+		name            string
+		err             error
+		wantStatus      int
+		wantTimeout     bool
+		wantRateLimited bool
+		wantRetryable   bool
+	}{
+		{name: "nil"},
+		{name: "other error", err: errors.New("boom")},
+		{name: "not found", err: statusErr(http.StatusNotFound), wantStatus: http.StatusNotFound},
+		{
+			name:          "server error",
+			err:           fmt.Errorf("fetch: %w", statusErr(http.StatusBadGateway)),
+			wantStatus:    http.StatusBadGateway,
+			wantRetryable: true,
+		},
+		{
+			name:            "rate limited",
+			err:             statusErr(http.StatusTooManyRequests),
+			wantStatus:      http.StatusTooManyRequests,
+			wantRateLimited: true,
+			wantRetryable:   true,
+		},
+		{
+			name:          "pointer response error",
+			err:           &ResponseError{Response: &http.Response{StatusCode: http.StatusTooEarly}},
+			wantStatus:    http.StatusTooEarly,
+			wantRetryable: true,
+		},
+		{name: "client timeout", err: ErrTimeout, wantTimeout: true, wantRetryable: true},
+		{
+			name:          "deadline exceeded",
+			err:           ResponseError{Original: urlErrorOf(context.DeadlineExceeded)},
+			wantTimeout:   true,
+			wantRetryable: true,
+		},
+		{name: "canceled", err: ResponseError{Original: urlErrorOf(context.Canceled)}},
+		{
+			name:          "connection refused",
+			err:           urlErrorOf(&net.OpError{Op: "dial", Err: errors.New("connection refused")}),
+			wantRetryable: true,
+		},
+		{name: "dropped connection", err: urlErrorOf(io.EOF), wantRetryable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.wantStatus, StatusCode(tt.err))
+			assert.Equal(t, tt.wantTimeout, IsTimeout(tt.err))
+			assert.Equal(t, tt.wantRateLimited, IsRateLimited(tt.err))
+			assert.Equal(t, tt.wantRetryable, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRequestError(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(WithRetryStrategy(RetryOn500x), WithMaxRetries(2), WithBackoffStrategy(NoBackoff()))
+
+	_, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+
+	var reqErr RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, 3, reqErr.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, reqErr.Response.StatusCode)
+	assert.Contains(t, err.Error(), "(after 3 attempts)")
+	assert.True(t, reqErr.Temporary())
+	assert.NotErrorIs(t, err, ErrTimeout)
+
+	// the former name still matches
+	var respErr ResponseError
+	require.ErrorAs(t, err, &respErr)
+
+	timeoutErr := RequestError{Original: urlErrorOf(context.DeadlineExceeded)}
+	require.ErrorIs(t, timeoutErr, ErrTimeout)
+	require.ErrorIs(t, timeoutErr, context.DeadlineExceeded)
+}
+
+func urlErrorOf(err error) error {
+	return &url.Error{Op: "Get", URL: "http://localhost", Err: err}
+}
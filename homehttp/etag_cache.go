@@ -0,0 +1,233 @@
+package homehttp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ETagCache remembers the ETag and Last-Modified validators of GET responses per URL and makes
+// the following requests conditional. A 304 Not Modified response is replaced by the cached one,
+// so the caller always gets the full body. It is cheaper than full HTTP caching for polling.
+type ETagCache struct {
+	entries    map[string]*etagEntry
+	order      []string
+	maxEntries int
+
+	mutex sync.Mutex
+}
+
+type etagEntry struct {
+	header       http.Header
+	vary         map[string]string
+	etag         string
+	lastModified string
+	body         []byte
+	status       int
+}
+
+// NewETagCache returns an ETagCache keeping up to maxEntries URLs, unlimited if it is not positive.
+func NewETagCache(maxEntries int) *ETagCache {
+	return &ETagCache{entries: make(map[string]*etagEntry), maxEntries: maxEntries}
+}
+
+// WithETagCache makes the client use the ETagCache for GET requests.
+// Requests with their own If-None-Match or If-Modified-Since headers are not affected.
+// Responses are cached per URL and Authorization header, so the cache can be shared by clients
+// with different credentials, and are only reused for requests matching their Vary headers.
+// Bodies larger than 10MB are not cached.
+func WithETagCache(cache *ETagCache) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.ETagCache = cache
+	})
+}
+
+// Len returns the number of cached URLs.
+func (c *ETagCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+func (c *ETagCache) middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+			return next.RoundTrip(req)
+		}
+
+		key := etagKey(req)
+
+		entry := c.get(key)
+		if entry != nil && !entry.matches(req) {
+			entry = nil
+		}
+
+		if entry != nil {
+			// the client retries the same request, it must not look like one with its own validators
+			req = req.Clone(req.Context())
+
+			if entry.etag != "" {
+				req.Header.Set("If-None-Match", entry.etag)
+			}
+
+			if entry.lastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.lastModified)
+			}
+		}
+
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified && entry != nil:
+			c.drain(resp.Body)
+
+			return entry.response(req), nil
+		case resp.StatusCode == http.StatusOK:
+			return c.store(key, req, resp)
+		default:
+			return resp, nil
+		}
+	})
+}
+
+func (c *ETagCache) get(key string) *etagEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.entries[key]
+}
+
+func (c *ETagCache) store(key string, req *http.Request, resp *http.Response) (*http.Response, error) {
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return resp, nil
+	}
+
+	vary, ok := varyValues(req, resp)
+	if !ok {
+		return resp, nil
+	}
+
+	// read one byte over the limit to tell a body of exactly the limit from a larger one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, respSizeLimit+1))
+	if err != nil {
+		_ = resp.Body.Close()
+
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	if int64(len(body)) > respSizeLimit {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+
+		return resp, nil
+	}
+
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.entries[key]; !ok {
+		c.order = append(c.order, key)
+	}
+
+	c.entries[key] = &etagEntry{
+		header:       resp.Header.Clone(),
+		vary:         vary,
+		etag:         etag,
+		lastModified: lastModified,
+		body:         body,
+		status:       resp.StatusCode,
+	}
+
+	if c.maxEntries > 0 && len(c.order) > c.maxEntries {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+
+	return resp, nil
+}
+
+func (c *ETagCache) drain(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, respSizeLimit))
+	_ = body.Close()
+}
+
+// etagKey identifies the cached response by the URL and the credentials of the request.
+func etagKey(req *http.Request) string {
+	key := req.URL.String()
+
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += " " + hex.EncodeToString(sum[:])
+	}
+
+	return key
+}
+
+// varyValues returns the values of the request headers listed in the Vary header of the response,
+// false if the response can't be reused, i.e. it varies on "*".
+func varyValues(req *http.Request, resp *http.Response) (map[string]string, bool) {
+	var vary map[string]string
+
+	for _, line := range resp.Header.Values("Vary") {
+		for _, name := range strings.Split(line, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+
+			switch name {
+			case "":
+				continue
+			case "*":
+				return nil, false
+			}
+
+			if vary == nil {
+				vary = make(map[string]string)
+			}
+
+			vary[name] = req.Header.Get(name)
+		}
+	}
+
+	return vary, true
+}
+
+// matches reports whether the request has the same values of the Vary headers as the cached one.
+func (e *etagEntry) matches(req *http.Request) bool {
+	for name, value := range e.vary {
+		if req.Header.Get(name) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e *etagEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
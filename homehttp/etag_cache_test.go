@@ -0,0 +1,199 @@
+package homehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDoWithETagCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		version  atomic.Int32
+		notModif atomic.Int32
+	)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"v` + string(rune('0'+version.Load())) + `"`
+
+		if r.URL.Path == "/modified" {
+			w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+
+			if r.Header.Get("If-Modified-Since") != "" {
+				notModif.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+
+				return
+			}
+
+			_, _ = io.WriteString(w, "modified body")
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+
+		if r.Header.Get("If-None-Match") == etag {
+			notModif.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, "body "+etag)
+	}))
+	t.Cleanup(testServer.Close)
+
+	cache := NewETagCache(10)
+	client := NewClient(WithETagCache(cache))
+
+	get := func(path string) (int, string) {
+		resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL+path, nil)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("/etag")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `body "v0"`, body)
+
+	status, body = get("/etag")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `body "v0"`, body)
+	assert.Equal(t, int32(1), notModif.Load())
+
+	version.Store(1)
+
+	_, body = get("/etag")
+	assert.Equal(t, `body "v1"`, body)
+
+	_, _ = get("/modified")
+	_, body = get("/modified")
+	assert.Equal(t, "modified body", body)
+	assert.Equal(t, int32(2), notModif.Load())
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestETagCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+	}))
+	t.Cleanup(testServer.Close)
+
+	cache := NewETagCache(2)
+	client := NewClient(WithETagCache(cache))
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL+path, nil)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	assert.Equal(t, 2, cache.Len())
+	assert.Nil(t, cache.get(testServer.URL+"/a"))
+}
+
+func TestClientDoWithETagCacheRetry(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v"`)
+
+		switch {
+		case calls.Add(1) == 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Header.Get("If-None-Match") == `"v"`:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			_, _ = io.WriteString(w, "cached body")
+		}
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(
+		WithETagCache(NewETagCache(10)),
+		WithRetryStrategy(RetryOn500x),
+		WithMaxRetries(1),
+		WithBackoffStrategy(NoBackoff()),
+	)
+
+	for range 2 {
+		resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "cached body", string(body))
+	}
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestETagCacheSharedByClients(t *testing.T) {
+	t.Parallel()
+
+	// the server sends the same ETag to everyone, the cache must not mix the bodies up
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v"`)
+		w.Header().Set("Vary", "Accept-Language")
+
+		if r.Header.Get("If-None-Match") == `"v"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		user, _, _ := r.BasicAuth()
+		_, _ = io.WriteString(w, user+" "+r.Header.Get("Accept-Language"))
+	}))
+	t.Cleanup(testServer.Close)
+
+	cache := NewETagCache(10)
+
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		wantBody string
+	}{
+		{name: "first credentials", opts: []ClientOption{WithBasicAuth("alice", "a")}, wantBody: "alice "},
+		{name: "other credentials", opts: []ClientOption{WithBasicAuth("bob", "b")}, wantBody: "bob "},
+		{
+			name:     "other vary header",
+			opts:     []ClientOption{WithBasicAuth("alice", "a"), WithHeader("Accept-Language", "uk")},
+			wantBody: "alice uk",
+		},
+		{name: "other credentials again", opts: []ClientOption{WithBasicAuth("bob", "b")}, wantBody: "bob "},
+	}
+
+	for _, tt := range tests {
+		client := NewClient(append(tt.opts, WithETagCache(cache))...)
+
+		resp, err := client.DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+		require.NoError(t, err, tt.name)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, tt.name)
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, tt.wantBody, string(body), tt.name)
+	}
+}
@@ -0,0 +1,315 @@
+package homehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	harVersion       = "1.2"
+	harRedactedValue = "[REDACTED]"
+
+	defaultHARMaxBodySize = 64 << 10
+)
+
+var ErrHARNotEnabled = errors.New("HAR recording is not enabled, see WithHARRecorder")
+
+// defaultHARRedactedHeaders are redacted in addition to the headers passed to WithHARRecorder.
+var defaultHARRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"} //nolint:gochecknoglobals
+
+// WithHARRecorder records the client traffic as a HAR 1.2 archive, which can be exported with Client.ExportHAR.
+// Only the last maxEntries requests are kept and bodies are truncated to maxBodySize bytes,
+// a non-positive maxBodySize truncates them to 64 KiB.
+// Values of the Authorization, Cookie and Set-Cookie headers and of the given headers are redacted.
+func WithHARRecorder(maxEntries int, maxBodySize int64, redactHeaders ...string) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.HAR = newHARRecorder(maxEntries, maxBodySize, redactHeaders)
+		c.TransportMiddlewares = append(c.TransportMiddlewares, c.HAR.middleware)
+	})
+}
+
+// ExportHAR writes the recorded traffic as a HAR 1.2 JSON document to w.
+func (c *Client) ExportHAR(w io.Writer) error {
+	if c.har == nil {
+		return ErrHARNotEnabled
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return errors.Wrap(enc.Encode(c.har.archive()), "failed to encode HAR")
+}
+
+type harRecorder struct {
+	redact      map[string]struct{}
+	entries     []*harEntry
+	maxEntries  int
+	maxBodySize int64
+
+	mutex sync.Mutex
+}
+
+type harArchive struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+
+	body *bytes.Buffer
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func newHARRecorder(maxEntries int, maxBodySize int64, redactHeaders []string) *harRecorder {
+	redact := make(map[string]struct{}, len(defaultHARRedactedHeaders)+len(redactHeaders))
+	for _, h := range append(defaultHARRedactedHeaders, redactHeaders...) {
+		redact[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+
+	if maxBodySize <= 0 {
+		maxBodySize = defaultHARMaxBodySize
+	}
+
+	return &harRecorder{redact: redact, maxEntries: maxEntries, maxBodySize: maxBodySize}
+}
+
+func (r *harRecorder) middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		reqBody, reqBodySize, err := r.peekBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		entry := &harEntry{
+			StartedDateTime: start.Format(time.RFC3339Nano),
+			Request: harRequest{
+				Method:      req.Method,
+				URL:         req.URL.String(),
+				HTTPVersion: req.Proto,
+				Cookies:     []harNameValue{},
+				Headers:     r.headers(req.Header),
+				QueryString: r.query(req),
+				HeadersSize: -1,
+				BodySize:    reqBodySize,
+			},
+		}
+
+		if len(reqBody) > 0 {
+			entry.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: string(reqBody)}
+		}
+
+		resp, rtErr := next.RoundTrip(req)
+		elapsed := float64(time.Since(start).Microseconds()) / 1000
+
+		entry.Time = elapsed
+		entry.Timings = harTimings{Wait: elapsed}
+		entry.Response = harResponse{Cookies: []harNameValue{}, Headers: []harNameValue{}, HeadersSize: -1, BodySize: -1}
+
+		if rtErr != nil {
+			entry.Comment = rtErr.Error()
+		} else {
+			entry.Response.Status = resp.StatusCode
+			entry.Response.StatusText = http.StatusText(resp.StatusCode)
+			entry.Response.HTTPVersion = resp.Proto
+			entry.Response.Headers = r.headers(resp.Header)
+			entry.Response.RedirectURL = resp.Header.Get("Location")
+			entry.Response.Content.MimeType = resp.Header.Get("Content-Type")
+
+			if resp.Body != nil {
+				entry.Response.Content.body = &bytes.Buffer{}
+				resp.Body = &harBody{ReadCloser: resp.Body, recorder: r, entry: entry}
+			}
+		}
+
+		r.add(entry)
+
+		return resp, rtErr
+	})
+}
+
+func (r *harRecorder) add(entry *harEntry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = append(r.entries, entry)
+	if r.maxEntries > 0 && len(r.entries) > r.maxEntries {
+		r.entries = r.entries[len(r.entries)-r.maxEntries:]
+	}
+}
+
+func (r *harRecorder) archive() harArchive {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries := make([]*harEntry, len(r.entries))
+
+	for i, e := range r.entries {
+		copied := *e
+		if body := copied.Response.Content.body; body != nil {
+			copied.Response.Content.Text = body.String()
+		}
+
+		entries[i] = &copied
+	}
+
+	return harArchive{Log: harLog{
+		Version: harVersion,
+		Creator: harCreator{Name: defaultUserAgent, Version: harVersion},
+		Entries: entries,
+	}}
+}
+
+func (r *harRecorder) headers(h http.Header) []harNameValue {
+	out := make([]harNameValue, 0, len(h))
+
+	for name, values := range h {
+		for _, v := range values {
+			if _, ok := r.redact[name]; ok {
+				v = harRedactedValue
+			}
+
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+
+	return out
+}
+
+func (r *harRecorder) query(req *http.Request) []harNameValue {
+	out := []harNameValue{}
+
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+
+	return out
+}
+
+// peekBody reads at most maxBodySize bytes of the request body and puts them back in front of the rest of it.
+// The returned size is -1 if the body is larger and its length is unknown.
+func (r *harRecorder) peekBody(req *http.Request) ([]byte, int64, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, 0, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(req.Body, r.maxBodySize+1))
+	if err != nil {
+		_ = req.Body.Close()
+
+		return nil, 0, errors.Wrap(err, "failed to read request body")
+	}
+
+	if int64(len(data)) <= r.maxBodySize {
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(data))
+
+		return data, int64(len(data)), nil
+	}
+
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), req.Body), req.Body}
+
+	return data[:r.maxBodySize], req.ContentLength, nil
+}
+
+// harBody records the response body into the entry while it is read by the caller.
+type harBody struct {
+	io.ReadCloser
+	recorder *harRecorder
+	entry    *harEntry
+}
+
+func (b *harBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	b.recorder.mutex.Lock()
+	content := &b.entry.Response.Content
+	content.Size += int64(n)
+	b.entry.Response.BodySize = content.Size
+
+	if room := b.recorder.maxBodySize - int64(content.body.Len()); room > 0 {
+		chunk := p[:n]
+		if int64(len(chunk)) > room {
+			chunk = chunk[:room]
+		}
+
+		content.body.Write(chunk)
+	}
+
+	b.recorder.mutex.Unlock()
+
+	return n, err
+}
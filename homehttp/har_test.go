@@ -0,0 +1,110 @@
+package homehttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientExportHAR(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = io.WriteString(w, "response body for "+r.URL.Query().Get("page"))
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(
+		WithBasicAuth("user", "pass"),
+		WithHeader("X-Api-Key", "key"),
+		WithHARRecorder(2, 8, "X-Api-Key"),
+	)
+
+	for _, page := range []string{"1", "2", "3"} {
+		resp, err := client.DoJSON(context.Background(), http.MethodPost, testServer.URL+"?page="+page, map[string]string{"page": page})
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, client.ExportHAR(&buf))
+	assert.NotContains(t, buf.String(), "secret")
+	assert.NotContains(t, buf.String(), `"key"`)
+
+	var archive harArchive
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &archive))
+
+	assert.Equal(t, "1.2", archive.Log.Version)
+	require.Len(t, archive.Log.Entries, 2, "only the last entries must be kept")
+
+	entry := archive.Log.Entries[1]
+	assert.Equal(t, http.MethodPost, entry.Request.Method)
+	assert.Equal(t, testServer.URL+"?page=3", entry.Request.URL)
+	assert.Equal(t, []harNameValue{{Name: "page", Value: "3"}}, entry.Request.QueryString)
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, `{"page":`, entry.Request.PostData.Text)
+	assert.Equal(t, http.StatusOK, entry.Response.Status)
+	assert.Equal(t, "OK", entry.Response.StatusText)
+	assert.Equal(t, "response", entry.Response.Content.Text)
+	assert.Equal(t, int64(len("response body for 3")), entry.Response.Content.Size)
+	assert.Contains(t, entry.Request.Headers, harNameValue{Name: "Authorization", Value: harRedactedValue})
+	assert.Contains(t, entry.Request.Headers, harNameValue{Name: "X-Api-Key", Value: harRedactedValue})
+	assert.Contains(t, entry.Response.Headers, harNameValue{Name: "Set-Cookie", Value: harRedactedValue})
+}
+
+func TestClientExportHARNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, NewClient().ExportHAR(io.Discard), ErrHARNotEnabled)
+}
+
+func TestClientExportHARLargeBodies(t *testing.T) {
+	t.Parallel()
+
+	payload := strings.Repeat("x", defaultHARMaxBodySize+10)
+	large := `"` + payload + "\"\n" // as encoded by DoJSON
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient(WithHARRecorder(1, 0))
+
+	resp, err := client.DoJSON(context.Background(), http.MethodPost, testServer.URL, payload)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, large, string(body), "the whole request body must be sent")
+
+	var buf bytes.Buffer
+
+	require.NoError(t, client.ExportHAR(&buf))
+
+	var archive harArchive
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &archive))
+	require.Len(t, archive.Log.Entries, 1)
+
+	entry := archive.Log.Entries[0]
+	assert.Equal(t, int64(len(large)), entry.Request.BodySize)
+	assert.Equal(t, large[:defaultHARMaxBodySize], entry.Request.PostData.Text)
+	assert.Equal(t, int64(len(large)), entry.Response.Content.Size)
+	assert.Equal(t, large[:defaultHARMaxBodySize], entry.Response.Content.Text)
+}
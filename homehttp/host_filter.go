@@ -0,0 +1,127 @@
+package homehttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrHostNotAllowed = errors.New("host is not allowed")
+	ErrBlockedAddress = errors.New("address is blocked")
+)
+
+// WithAllowedHosts rejects requests, including redirects, to hosts not matching any of the patterns
+// with ErrHostNotAllowed. A pattern is a host name, e.g. "api.example.com", or a wildcard matching
+// its subdomains, e.g. "*.example.com". Other patterns with "*" match nothing.
+func WithAllowedHosts(patterns ...string) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.TransportMiddlewares = append(c.TransportMiddlewares, clientAllowedHosts(patterns))
+	})
+}
+
+// WithBlockPrivateNetworks rejects connections to private (RFC 1918, RFC 4193), loopback, link-local
+// and unspecified addresses with ErrBlockedAddress. The check is done on the resolved address right
+// before dialing, so it also covers host names pointing to such addresses.
+//
+// The requests sent through a proxy, see WithProxy, have the target host resolved and checked
+// before they are sent to the proxy, the proxy itself has to be on a public address too.
+func WithBlockPrivateNetworks() ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.BlockPrivateNetworks = true
+	})
+}
+
+// clientAllowedHosts rejects requests to hosts not matching the patterns.
+func clientAllowedHosts(patterns []string) roundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if host := req.URL.Hostname(); !hostAllowed(host, patterns) {
+				return nil, errors.Wrap(ErrHostNotAllowed, host)
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func hostAllowed(host string, patterns []string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+			// the suffix starts with a dot, so "*.example.com" doesn't match "evilexample.com"
+			if strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return true
+			}
+
+			continue
+		}
+
+		if host == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+// blockPrivateNetworks is a net.Dialer control function rejecting non-public addresses.
+func blockPrivateNetworks(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return checkPublicAddr(ip)
+}
+
+// blockPrivateTargets wraps the proxy function of a transport, rejecting the proxied requests
+// to hosts resolving to non-public addresses. The dialer only sees the address of the proxy.
+func blockPrivateTargets(
+	proxy func(*http.Request) (*url.URL, error), lookup func(context.Context, string, string) ([]netip.Addr, error),
+) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := proxy(req)
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+
+		host := req.URL.Hostname()
+
+		ips, err := lookup(req.Context(), "ip", host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve %s", host)
+		}
+
+		for _, ip := range ips {
+			if err := checkPublicAddr(ip); err != nil {
+				return nil, err
+			}
+		}
+
+		return proxyURL, nil
+	}
+}
+
+func checkPublicAddr(ip netip.Addr) error {
+	ip = ip.Unmap()
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return errors.Wrap(ErrBlockedAddress, ip.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package homehttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostAllowed(t *testing.T) {
+	t.Parallel()
+
+	patterns := []string{"api.example.com", "*.internal.example.com", "*example.org"}
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "api.example.com", want: true},
+		{host: "API.example.com.", want: true},
+		{host: "svc.internal.example.com", want: true},
+		{host: "a.b.internal.example.com", want: true},
+		{host: "internal.example.com", want: false},
+		{host: "evil-internal.example.com", want: false},
+		{host: "example.com", want: false},
+		{host: "api.example.com.evil.com", want: false},
+		{host: "evilexample.org", want: false},
+		{host: "api.example.org", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, hostAllowed(tt.host, patterns))
+		})
+	}
+}
+
+func TestBlockPrivateNetworks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		blocked bool
+	}{
+		{address: "10.1.2.3:80", blocked: true},
+		{address: "172.16.0.1:80", blocked: true},
+		{address: "192.168.1.1:443", blocked: true},
+		{address: "127.0.0.1:80", blocked: true},
+		{address: "169.254.169.254:80", blocked: true},
+		{address: "0.0.0.0:80", blocked: true},
+		{address: "[::1]:80", blocked: true},
+		{address: "[fd00::1]:80", blocked: true},
+		{address: "[fe80::1]:80", blocked: true},
+		{address: "[::ffff:10.0.0.1]:80", blocked: true},
+		{address: "8.8.8.8:53", blocked: false},
+		{address: "[2001:4860:4860::8888]:443", blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			t.Parallel()
+
+			err := blockPrivateNetworks("tcp", tt.address, nil)
+			if tt.blocked {
+				require.ErrorIs(t, err, ErrBlockedAddress)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBlockPrivateTargets(t *testing.T) {
+	t.Parallel()
+
+	proxyURL := &url.URL{Scheme: "http", Host: "proxy.test:3128"}
+	lookup := func(_ context.Context, _, host string) ([]netip.Addr, error) {
+		if host == "internal.test" {
+			return []netip.Addr{netip.MustParseAddr("8.8.8.8"), netip.MustParseAddr("10.0.0.1")}, nil
+		}
+
+		return net.DefaultResolver.LookupNetIP(context.Background(), "ip", host)
+	}
+
+	tests := []struct {
+		target  string
+		blocked bool
+	}{
+		{target: "http://10.0.0.1/path", blocked: true},
+		{target: "http://[::1]:8080", blocked: true},
+		{target: "http://internal.test", blocked: true},
+		{target: "https://8.8.8.8/dns", blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, tt.target, nil)
+			require.NoError(t, err)
+
+			got, err := blockPrivateTargets(http.ProxyURL(proxyURL), lookup)(req)
+			if tt.blocked {
+				require.ErrorIs(t, err, ErrBlockedAddress)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, proxyURL, got)
+		})
+	}
+}
+
+func TestClientDoWithHostFilters(t *testing.T) {
+	t.Parallel()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantErr error
+	}{
+		{name: "Allowed host", opts: []ClientOption{WithAllowedHosts("127.0.0.1")}},
+		{name: "Not allowed host", opts: []ClientOption{WithAllowedHosts("example.com")}, wantErr: ErrHostNotAllowed},
+		{name: "Private network blocked", opts: []ClientOption{WithBlockPrivateNetworks()}, wantErr: ErrBlockedAddress},
+		{
+			name:    "Private network blocked with proxy",
+			opts:    []ClientOption{WithBlockPrivateNetworks(), WithProxy(&url.URL{Scheme: "http", Host: "proxy.test:3128"})},
+			wantErr: ErrBlockedAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := NewClient(tt.opts...).DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package homehttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDoWithIdempotentOnlyRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		method        string
+		expectedCalls int32
+	}{
+		{name: "GET is retried", method: http.MethodGet, expectedCalls: 3},
+		{name: "POST is not retried on 5xx", method: http.MethodPost, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			t.Cleanup(testServer.Close)
+
+			client := NewClient(
+				WithRetryStrategy(RetryOn500x),
+				WithMaxRetries(2),
+				WithBackoffStrategy(NoBackoff()),
+				WithIdempotentOnlyRetries(),
+			)
+
+			resp, err := client.DoJSON(context.Background(), tt.method, testServer.URL, nil)
+			if err == nil {
+				require.NoError(t, resp.Body.Close())
+			}
+
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestIsNotSentError(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	_, err = http.Get("http://" + addr) //nolint:noctx,bodyclose
+	require.Error(t, err)
+	assert.True(t, isNotSentError(err), "refused connection must be recognized: %v", err)
+
+	assert.False(t, isNotSentError(context.DeadlineExceeded))
+	assert.False(t, isNotSentError(nil))
+}
+
+func TestSafeDefaults(t *testing.T) {
+	t.Parallel()
+
+	cfg := &clientConfig{}
+	SafeDefaults().apply(cfg)
+	WithMaxRetries(5).apply(cfg)
+
+	assert.True(t, cfg.IdempotentOnlyRetries)
+	assert.Equal(t, 5, cfg.MaxRetries)
+	assert.Equal(t, defaultMaxRetryWait, cfg.MaxRetryWait)
+	assert.NotNil(t, cfg.Retryer)
+	assert.Equal(t, respSizeLimit, cfg.DecompressionLimit)
+}
@@ -2,6 +2,7 @@ package homehttp
 
 import (
 	"context"
+	"net/url"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -74,15 +75,30 @@ func WithHeader(key, value string) ClientOption {
 }
 
 // WithRetryStrategy returns a ClientOption that adds a RetryMiddleware to the client's transport middlewares.
+// WithRetryStrategy replaces the default retry policy. Unless WithMaxRetries is given as well,
+// the client doesn't retry, so the strategy takes effect once the number of retries is set.
 func WithRetryStrategy(strategy RetryStrategy) ClientOption {
 	return clientOptionFn(func(c *clientConfig) {
 		c.Retryer = strategy
+
+		if !c.MaxRetriesSet {
+			c.MaxRetries = 0
+		}
 	})
 }
 
 func WithMaxRetries(maxRetries int) ClientOption {
 	return clientOptionFn(func(c *clientConfig) {
 		c.MaxRetries = maxRetries
+		c.MaxRetriesSet = true
+	})
+}
+
+// WithMaxRetryWait caps every wait between retries, including the one requested with Retry-After.
+// The default is 30s, zero removes the cap.
+func WithMaxRetryWait(d time.Duration) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.MaxRetryWait = d
 	})
 }
 
@@ -95,3 +111,55 @@ func WithBackoffStrategy(strategy BackoffStrategy) ClientOption {
 func WithConstantBackoff(t time.Duration) ClientOption {
 	return WithBackoffStrategy(ConstantBackoff(t))
 }
+
+// WithStatusBackoffOverride sets fixed retry waits for response statuses, e.g. 1s for 502 and 30s for 429.
+// Responses with a Retry-After header wait for it, capped at the max retry wait if it is set,
+// other statuses use the backoff strategy.
+func WithStatusBackoffOverride(waits map[int]time.Duration) ClientOption {
+	return WithStatusBackoffOverrideFunc(func(status, _ int) (time.Duration, bool) {
+		wait, ok := waits[status]
+
+		return wait, ok
+	})
+}
+
+// WithStatusBackoffOverrideFunc works as WithStatusBackoffOverride, the wait is returned by fn
+// for the status and the zero-based attempt; the backoff strategy is used if fn returns false.
+func WithStatusBackoffOverrideFunc(fn func(status, attemptNum int) (time.Duration, bool)) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.StatusBackoff = fn
+	})
+}
+
+// WithIdempotentOnlyRetries limits the retries of non-idempotent requests, e.g. POST or PATCH,
+// to failures proving the request was not sent, such as a refused connection, to avoid duplicate side effects.
+func WithIdempotentOnlyRetries() ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.IdempotentOnlyRetries = true
+	})
+}
+
+// SafeDefaults is a preset of conservative retry and response handling options:
+// up to 3 retries on 5xx, 408 and 425 responses with idempotent-only retries, waits honoring Retry-After
+// capped at 30s and a decompressed response size limit. Options passed after it override its settings.
+func SafeDefaults() ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		for _, o := range []ClientOption{
+			WithRetryStrategy(MultiRetryStrategies{RetryOn500x, RetryOnRequestTimeout}),
+			WithMaxRetries(safeDefaultsMaxRetries),
+			WithBackoffStrategy(RetryAfterBackoff(ConstantBackoff(defaultBackoffTime))),
+			WithIdempotentOnlyRetries(),
+			WithDecompressionLimit(respSizeLimit),
+			WithMaxRetryWait(defaultMaxRetryWait),
+		} {
+			o.apply(c)
+		}
+	})
+}
+
+// WithProxy sends all requests through the proxy instead of the one from the HTTP_PROXY and HTTPS_PROXY variables.
+func WithProxy(proxy *url.URL) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.Proxy = proxy
+	})
+}
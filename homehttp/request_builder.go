@@ -0,0 +1,108 @@
+package homehttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// RequestBuilder builds a request step by step and executes it with the client, see Client.NewRequest.
+type RequestBuilder struct {
+	client  *Client
+	method  string
+	url     string
+	query   url.Values
+	headers http.Header
+	body    any
+}
+
+// NewRequest returns a RequestBuilder for a GET request executed by the client:
+//
+//	resp, err := client.NewRequest().Method(http.MethodPost).URL(u).Query("page", 2).JSONBody(v).Do(ctx)
+func (c *Client) NewRequest() *RequestBuilder {
+	return &RequestBuilder{
+		client:  c,
+		method:  http.MethodGet,
+		query:   url.Values{},
+		headers: http.Header{},
+	}
+}
+
+// Method sets the request method.
+func (b *RequestBuilder) Method(method string) *RequestBuilder {
+	b.method = method
+
+	return b
+}
+
+// URL sets the request URL, the query parameters added by Query are merged with its query.
+func (b *RequestBuilder) URL(u string) *RequestBuilder {
+	b.url = u
+
+	return b
+}
+
+// Query adds a query parameter, the value is formatted with fmt.Sprint.
+func (b *RequestBuilder) Query(key string, value any) *RequestBuilder {
+	b.query.Add(key, fmt.Sprint(value))
+
+	return b
+}
+
+// Header sets a request header. Headers set by the client with WithHeader take precedence.
+func (b *RequestBuilder) Header(key, value string) *RequestBuilder {
+	b.headers.Set(key, value)
+
+	return b
+}
+
+// JSONBody sets the payload encoded as the JSON request body.
+func (b *RequestBuilder) JSONBody(payload any) *RequestBuilder {
+	b.body = payload
+
+	return b
+}
+
+// Build returns the request without executing it.
+func (b *RequestBuilder) Build(ctx context.Context) (*http.Request, error) {
+	u, err := url.Parse(b.url)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse url")
+	}
+
+	if len(b.query) > 0 {
+		q := u.Query()
+
+		for k, values := range b.query {
+			for _, v := range values {
+				q.Add(k, v)
+			}
+		}
+
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := NewRequestJSON(ctx, b.method, u.String(), b.body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, values := range b.headers {
+		req.Header[k] = values
+	}
+
+	return req, nil
+}
+
+// Do builds and executes the request the same way as Client.DoJSON does.
+func (b *RequestBuilder) Do(ctx context.Context) (*http.Response, error) {
+	req, err := b.Build(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	return b.client.do(ctx, req)
+}
@@ -0,0 +1,63 @@
+package homehttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBuilderDo(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body payload
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/items", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		assert.Equal(t, "asc", r.URL.Query().Get("sort"))
+		assert.Equal(t, "42", r.Header.Get("X-Id"))
+		assert.Equal(t, defaultContentType, r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "widget", body.Name)
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(testServer.Close)
+
+	resp, err := NewClient().NewRequest().
+		Method(http.MethodPost).
+		URL(testServer.URL+"/items?sort=asc").
+		Query("page", 2).
+		Header("X-Id", "42").
+		JSONBody(payload{Name: "widget"}).
+		Do(context.Background())
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestRequestBuilderBuild(t *testing.T) {
+	t.Parallel()
+
+	req, err := NewClient().NewRequest().URL("http://localhost/path").Query("a", 1).Query("a", true).Build(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "http://localhost/path?a=1&a=true", req.URL.String())
+	assert.Nil(t, req.Body)
+
+	_, err = NewClient().NewRequest().URL("://invalid").Do(context.Background())
+	require.Error(t, err)
+}
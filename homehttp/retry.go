@@ -2,6 +2,8 @@ package homehttp
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 )
 
@@ -41,10 +43,36 @@ var (
 	RetryOn500x = RetryStrategyFunc(func(_ context.Context, resp *http.Response, _ error) bool {
 		return resp != nil && resp.StatusCode >= http.StatusInternalServerError
 	})
+
+	// RetryOnRequestTimeout is a classifier that retries idempotent requests on 408 Request Timeout
+	// and 425 Too Early. It is the default policy of the client, combine it with other strategies
+	// to keep it when using WithRetryStrategy.
+	RetryOnRequestTimeout = RetryStrategyFunc(func(_ context.Context, resp *http.Response, _ error) bool {
+		return resp != nil && resp.Request != nil && IsIdempotent(resp.Request.Method) &&
+			(resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooEarly)
+	})
 )
 
+// IsIdempotent reports whether the method is idempotent, so a request can be safely retried.
+func IsIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 type NoRetryStrategy struct{}
 
 func (s *NoRetryStrategy) Classify(_ context.Context, _ *http.Response, _ error) bool {
 	return false
 }
+
+// isNotSentError reports whether the error proves that the request was not sent,
+// i.e. the connection could not be established.
+func isNotSentError(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,157 @@
+package homehttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryOnRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		method        string
+		opts          []ClientOption
+		status        int
+		expectedCalls int32
+	}{
+		{name: "GET retried on 408", method: http.MethodGet, status: http.StatusRequestTimeout, expectedCalls: 2},
+		{name: "HEAD retried on 425", method: http.MethodHead, status: http.StatusTooEarly, expectedCalls: 2},
+		{name: "POST not retried on 408", method: http.MethodPost, status: http.StatusRequestTimeout, expectedCalls: 1},
+		{name: "GET not retried on 400", method: http.MethodGet, status: http.StatusBadRequest, expectedCalls: 1},
+		{
+			name:   "GET retried on 408 with composed strategy",
+			method: http.MethodGet,
+			opts: []ClientOption{
+				WithRetryStrategy(MultiRetryStrategies{RetryOn500x, RetryOnRequestTimeout}),
+				WithBackoffStrategy(RetryAfterBackoff(ConstantBackoff(time.Minute))),
+				WithMaxRetries(1),
+			},
+			status:        http.StatusRequestTimeout,
+			expectedCalls: 2,
+		},
+		{
+			name:          "GET retried with a strategy and retries given first",
+			method:        http.MethodGet,
+			opts:          []ClientOption{WithMaxRetries(1), WithRetryStrategy(RetryOnRequestTimeout)},
+			status:        http.StatusRequestTimeout,
+			expectedCalls: 2,
+		},
+		{
+			name:          "GET not retried with another strategy",
+			method:        http.MethodGet,
+			opts:          []ClientOption{WithRetryStrategy(RetryOn500x), WithMaxRetries(1)},
+			status:        http.StatusRequestTimeout,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if calls.Add(1) == 1 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.status)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(testServer.Close)
+
+			// the default client retries 408 and 425 once, its default wait of 300ms is overridden by Retry-After
+			client := NewClient(tt.opts...)
+
+			resp, err := client.DoJSON(context.Background(), tt.method, testServer.URL, nil)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestDefaultBackoffHonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{StatusCode: http.StatusTooEarly, Header: http.Header{"Retry-After": []string{"7"}}}
+
+	assert.Equal(t, 7*time.Second, NewClient().backoff.Backoff(0, 0, 0, resp))
+	assert.Equal(t, defaultBackoffTime, NewClient().backoff.Backoff(0, 0, 0, &http.Response{Header: http.Header{}}))
+}
+
+func TestRetryStrategyWithoutMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, defaultRetries, NewClient().maxRetries)
+	assert.Equal(t, 0, NewClient(WithRetryStrategy(RetryOn500x)).maxRetries)
+	assert.Equal(t, 2, NewClient(WithMaxRetries(2), WithRetryStrategy(RetryOn500x)).maxRetries)
+	assert.Equal(t, 2, NewClient(WithRetryStrategy(RetryOn500x), WithMaxRetries(2)).maxRetries)
+}
+
+func TestDefaultClientCapsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusRequestTimeout)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	clock := &instantClock{}
+
+	resp, err := NewClient(WithClock(clock)).DoJSON(context.Background(), http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, []time.Duration{defaultMaxRetryWait}, clock.waits)
+}
+
+// fixedClock is an instantClock whose time doesn't pass.
+type fixedClock struct {
+	instantClock
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time { return c.now }
+
+func TestRetryAfterDateUsesClientClock(t *testing.T) {
+	t.Parallel()
+
+	clock := &fixedClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{clock.now.Add(10 * time.Second).Format(http.TimeFormat)}}}
+
+	assert.Equal(t, 10*time.Second, NewClient(WithClock(clock)).backoff.Backoff(0, 0, 0, resp))
+	assert.Equal(t, 10*time.Second, NewClient(WithClock(clock), WithStatusBackoffOverride(map[int]time.Duration{http.StatusTooManyRequests: time.Minute})).
+		backoff.Backoff(0, 0, 0, resp))
+}
+
+func TestIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsIdempotent(http.MethodGet))
+	assert.True(t, IsIdempotent(http.MethodPut))
+	assert.False(t, IsIdempotent(http.MethodPost))
+	assert.False(t, IsIdempotent(http.MethodPatch))
+}
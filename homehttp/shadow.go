@@ -0,0 +1,152 @@
+package homehttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ShadowHeader marks the mirrored requests, so the shadow target can tell them from the real traffic.
+	ShadowHeader = "X-Shadow-Request"
+
+	defaultShadowRate    = 10 // requests per second
+	defaultShadowTimeout = 10 * time.Second
+)
+
+// shadowCredentialHeaders aren't mirrored unless WithShadowCredentials is used.
+var shadowCredentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"} //nolint:gochecknoglobals
+
+// WithShadowTraffic asynchronously mirrors the sampled requests to the host of targetBaseURL for dark-launch testing,
+// sampleRate is the fraction of mirrored requests from 0 to 1. The path of targetBaseURL prefixes the request path.
+// Responses of the shadow target are discarded and never affect the client. A request is mirrored once,
+// its retries aren't. The mirrored requests are limited to 10 per second by default, see WithShadowRateLimit,
+// the requests over the limit aren't mirrored. An invalid URL disables the mirroring.
+//
+// The Authorization, Proxy-Authorization and Cookie headers aren't sent to the shadow target,
+// see WithShadowCredentials. Other headers carrying credentials, e.g. API keys, are mirrored.
+func WithShadowTraffic(targetBaseURL string, sampleRate float64) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		target, err := url.Parse(targetBaseURL)
+		if err != nil || target.Host == "" || sampleRate <= 0 {
+			c.Shadow = nil
+
+			return
+		}
+
+		c.Shadow = &shadowMirror{
+			target:     target,
+			sampleRate: sampleRate,
+			client:     &http.Client{Timeout: defaultShadowTimeout},
+			limiter:    &shadowLimiter{rate: defaultShadowRate, tokens: defaultShadowRate},
+		}
+	})
+}
+
+// WithShadowRateLimit changes the limit of the requests mirrored by WithShadowTraffic, in requests per second.
+func WithShadowRateLimit(perSecond float64) ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.ShadowRateLimit = perSecond
+	})
+}
+
+// WithShadowCredentials makes WithShadowTraffic send the credential headers to the shadow target as well.
+// Use it only if the shadow target is trusted with the production credentials.
+func WithShadowCredentials() ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.ShadowCredentials = true
+	})
+}
+
+type shadowMirror struct {
+	target      *url.URL
+	client      *http.Client
+	limiter     *shadowLimiter
+	sampleRate  float64
+	credentials bool
+}
+
+func (s *shadowMirror) middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if isRetryAttempt(req.Context()) || rand.Float64() >= s.sampleRate || !s.limiter.allow() { //nolint:gosec
+			return next.RoundTrip(req)
+		}
+
+		body, err := readAndRestoreBody(&req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		go s.send(s.shadowRequest(req, body))
+
+		return next.RoundTrip(req)
+	})
+}
+
+func (s *shadowMirror) shadowRequest(req *http.Request, body []byte) *http.Request {
+	// the shadow request must outlive the original one
+	shadow := req.Clone(context.WithoutCancel(req.Context()))
+	shadow.Host = ""
+	shadow.URL.Scheme = s.target.Scheme
+	shadow.URL.Host = s.target.Host
+	shadow.URL.User = s.target.User
+	shadow.URL.Path = strings.TrimSuffix(s.target.Path, "/") + req.URL.Path
+	shadow.URL.RawPath = ""
+	shadow.Header.Set(ShadowHeader, "true")
+	shadow.Body = io.NopCloser(bytes.NewReader(body))
+	shadow.GetBody = nil
+
+	if !s.credentials {
+		for _, h := range shadowCredentialHeaders {
+			shadow.Header.Del(h)
+		}
+	}
+
+	return shadow
+}
+
+func (s *shadowMirror) send(shadow *http.Request) {
+	resp, err := s.client.Do(shadow)
+	if err != nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, respSizeLimit))
+	_ = resp.Body.Close()
+}
+
+// shadowLimiter is a token bucket refilled with rate tokens per second up to rate tokens.
+type shadowLimiter struct {
+	now    func() time.Time
+	last   time.Time
+	rate   float64
+	tokens float64
+
+	mutex sync.Mutex
+}
+
+func (l *shadowLimiter) allow() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+
+	l.last = now
+
+	if l.tokens < 1 {
+		return false
+	}
+
+	l.tokens--
+
+	return true
+}
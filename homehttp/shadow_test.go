@@ -0,0 +1,204 @@
+package homehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type shadowedRequest struct {
+	method string
+	path   string
+	body   string
+	header http.Header
+}
+
+func TestWithShadowTraffic(t *testing.T) {
+	t.Parallel()
+
+	mirrored := make(chan shadowedRequest, 10)
+
+	shadowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mirrored <- shadowedRequest{method: r.Method, path: r.URL.Path, body: string(body), header: r.Header}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(shadowServer.Close)
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(primary.Close)
+
+	client := NewClient(
+		WithShadowTraffic(shadowServer.URL+"/v2/", 1),
+		WithHeader("X-Api-Key", "secret"),
+		WithHeader("Cookie", "session=secret"),
+		WithBasicAuth("user", "secret"),
+	)
+
+	resp, err := client.DoJSON(context.Background(), http.MethodPost, primary.URL+"/items", map[string]int{"id": 1})
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"id":1}`, string(body))
+
+	select {
+	case req := <-mirrored:
+		assert.Equal(t, http.MethodPost, req.method)
+		assert.Equal(t, "/v2/items", req.path)
+		assert.JSONEq(t, `{"id":1}`, req.body)
+		assert.Equal(t, "true", req.header.Get(ShadowHeader))
+		assert.Equal(t, "secret", req.header.Get("X-Api-Key"))
+		assert.Empty(t, req.header.Get("Authorization"))
+		assert.Empty(t, req.header.Get("Cookie"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not mirrored")
+	}
+}
+
+func TestWithShadowTraffic_Credentials(t *testing.T) {
+	t.Parallel()
+
+	mirrored := make(chan http.Header, 10)
+
+	shadowServer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		mirrored <- r.Header
+	}))
+	t.Cleanup(shadowServer.Close)
+
+	primary := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	t.Cleanup(primary.Close)
+
+	client := NewClient(
+		WithShadowTraffic(shadowServer.URL, 1),
+		WithShadowCredentials(),
+		WithHeader("Cookie", "session=secret"),
+		WithBasicAuth("user", "secret"),
+	)
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, primary.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	select {
+	case header := <-mirrored:
+		assert.NotEmpty(t, header.Get("Authorization"))
+		assert.Equal(t, "session=secret", header.Get("Cookie"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not mirrored")
+	}
+}
+
+func TestWithShadowTraffic_MirrorsFirstAttemptOnly(t *testing.T) {
+	t.Parallel()
+
+	mirrored := make(chan struct{}, 10)
+
+	shadowServer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		mirrored <- struct{}{}
+	}))
+	t.Cleanup(shadowServer.Close)
+
+	var calls atomic.Int32
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(primary.Close)
+
+	client := NewClient(WithShadowTraffic(shadowServer.URL, 1), WithRetryStrategy(RetryOn500x), WithMaxRetries(2), WithBackoffStrategy(NoBackoff()))
+
+	resp, err := client.DoJSON(context.Background(), http.MethodGet, primary.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+
+	select {
+	case <-mirrored:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not mirrored")
+	}
+
+	select {
+	case <-mirrored:
+		t.Fatal("retry was mirrored")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWithShadowRateLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg := &clientConfig{Clock: &instantClock{}}
+	WithShadowTraffic("http://localhost", 1).apply(cfg)
+	WithShadowRateLimit(2).apply(cfg)
+	buildClient(cfg)
+
+	var allowed int
+
+	for range 5 {
+		if cfg.Shadow.limiter.allow() {
+			allowed++
+		}
+	}
+
+	assert.Equal(t, 2, allowed)
+}
+
+func TestWithShadowTraffic_Disabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		target     string
+		sampleRate float64
+	}{
+		{name: "zero sample rate", target: "http://localhost", sampleRate: 0},
+		{name: "invalid URL", target: "://localhost", sampleRate: 1},
+		{name: "no host", target: "/v2", sampleRate: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &clientConfig{}
+			WithShadowTraffic(tt.target, tt.sampleRate).apply(cfg)
+
+			assert.Nil(t, cfg.Shadow)
+		})
+	}
+}
+
+func TestShadowLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := &shadowLimiter{rate: 2, tokens: 2, now: func() time.Time { return now }}
+
+	var allowed []string
+
+	for _, step := range []time.Duration{0, 0, 0, 500 * time.Millisecond, 0, 2 * time.Second, 0, 0, 0} {
+		now = now.Add(step)
+		allowed = append(allowed, map[bool]string{true: "+", false: "-"}[limiter.allow()])
+	}
+
+	assert.Equal(t, "++-+-++--", strings.Join(allowed, ""))
+}
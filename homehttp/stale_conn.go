@@ -0,0 +1,66 @@
+package homehttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"syscall"
+)
+
+// WithoutStaleConnRedial disables the re-dial of requests failed on a stale keep-alive connection.
+func WithoutStaleConnRedial() ClientOption {
+	return clientOptionFn(func(c *clientConfig) {
+		c.DisableStaleConnRedial = true
+	})
+}
+
+// redialStaleConn repeats an idempotent request once on a new connection if it failed on a reused keep-alive
+// connection closed by the server, e.g. with "connection reset by peer" or EOF.
+// The repeat is done by the transport and doesn't count as a retry of the client's retry strategy.
+// http.Transport does the same only for requests without side effects, i.e. GET, HEAD, OPTIONS and TRACE.
+func redialStaleConn(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var reused bool
+
+		ctx := httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+			GotConn: func(info httptrace.GotConnInfo) {
+				reused = info.Reused
+			},
+		})
+
+		resp, err := next.RoundTrip(req.WithContext(ctx))
+		if err == nil || !reused || !IsIdempotent(req.Method) || !isStaleConnError(err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		retry := req.Clone(req.Context())
+
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+
+			retry.Body = body
+		}
+
+		// the other idle connections to the server are likely stale as well
+		if closer, ok := next.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+		}
+
+		return next.RoundTrip(retry)
+	})
+}
+
+func isStaleConnError(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
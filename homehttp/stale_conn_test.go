@@ -0,0 +1,111 @@
+package homehttp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staleConnServer responds to the requests keeping the connection alive,
+// but closes the connection without a response on the second request.
+func staleConnServer(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	var (
+		requests atomic.Int32
+		conns    atomic.Int32
+	)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			conns.Add(1)
+
+			go func() {
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+
+				for {
+					req, err := http.ReadRequest(reader)
+					if err != nil {
+						return
+					}
+
+					_, _ = io.Copy(io.Discard, req.Body)
+
+					if requests.Add(1) == 2 {
+						return
+					}
+
+					_, _ = io.WriteString(conn, "HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok")
+				}
+			}()
+		}
+	}()
+
+	return "http://" + listener.Addr().String(), &conns
+}
+
+// The test isn't parallel: httptest.Server.Close of the other tests closes the idle connections
+// of http.DefaultTransport, so the connection wouldn't be reused.
+func TestClient_StaleConnRedial(t *testing.T) { //nolint:paralleltest
+
+	tests := []struct {
+		name      string
+		method    string
+		opts      []ClientOption
+		wantErr   bool
+		wantConns int32
+	}{
+		{name: "idempotent request is re-dialed", method: http.MethodPut, wantConns: 2},
+		{name: "non-idempotent request fails", method: http.MethodPost, wantErr: true, wantConns: 1},
+		{
+			name:      "re-dial disabled",
+			method:    http.MethodDelete,
+			opts:      []ClientOption{WithoutStaleConnRedial()},
+			wantErr:   true,
+			wantConns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, conns := staleConnServer(t)
+			client := NewClient(tt.opts...)
+
+			// the body is read to return the connection into the pool
+			resp, err := client.DoJSON(context.Background(), http.MethodGet, url, nil)
+			require.NoError(t, err)
+			_, err = io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			resp, err = client.DoJSON(context.Background(), tt.method, url, map[string]string{"key": "value"})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+
+			assert.Equal(t, tt.wantConns, conns.Load())
+		})
+	}
+}
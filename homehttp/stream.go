@@ -0,0 +1,95 @@
+package homehttp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// StreamJSON executes the request with DoJSON and decodes a successful (2xx) response
+// incrementally, either as newline-delimited JSON or as a top-level JSON array.
+// The returned sequence is compatible with iter.Seq2[T, error]; it yields a decoding or
+// read error at most once and stops. The response body is closed when the iteration
+// ends, so the sequence must be consumed.
+// Transport failures and non-2xx statuses are returned as RequestError, like DoJSONAs.
+func StreamJSON[T any](
+	ctx context.Context, c *Client, method, url string, payload any,
+) (func(yield func(T, error) bool), error) {
+	resp, err := c.DoJSON(ctx, method, url, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSuccess(resp) {
+		return nil, bufferedRequestError(resp)
+	}
+
+	return func(yield func(T, error) bool) {
+		defer resp.Body.Close()
+
+		decodeStream(bufio.NewReader(resp.Body), yield)
+	}, nil
+}
+
+func decodeStream[T any](r *bufio.Reader, yield func(T, error) bool) {
+	var zero T
+
+	isArray, err := startsWithArray(r)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			yield(zero, errors.Wrap(err, "failed to read stream"))
+		}
+
+		return
+	}
+
+	dec := json.NewDecoder(r)
+
+	if isArray {
+		if _, err = dec.Token(); err != nil {
+			yield(zero, errors.Wrap(err, "failed to decode stream"))
+
+			return
+		}
+	}
+
+	for !isArray || dec.More() {
+		var v T
+
+		if err = dec.Decode(&v); err != nil {
+			if !isArray && errors.Is(err, io.EOF) {
+				return
+			}
+
+			yield(zero, errors.Wrap(err, "failed to decode stream element"))
+
+			return
+		}
+
+		if !yield(v, nil) {
+			return
+		}
+	}
+
+	if _, err = dec.Token(); err != nil {
+		yield(zero, errors.Wrap(err, "failed to decode end of stream"))
+	}
+}
+
+// startsWithArray skips leading whitespace and reports whether the stream is a JSON array.
+func startsWithArray(r *bufio.Reader) (bool, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return false, err
+		}
+
+		if !unicode.IsSpace(rune(b)) {
+			return b == '[', r.UnreadByte()
+		}
+	}
+}
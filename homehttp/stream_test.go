@@ -0,0 +1,120 @@
+package homehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamJSON(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ndjson":
+			_, _ = io.WriteString(w, "{\"id\":1}\n{\"id\":2}\n\n{\"id\":3}\n")
+		case "/array":
+			_, _ = io.WriteString(w, " [{\"id\":1},\n{\"id\":2}, {\"id\":3}]")
+		case "/empty":
+		case "/truncated":
+			_, _ = io.WriteString(w, `[{"id":1},{"id":`)
+		case "/malformed":
+			_, _ = io.WriteString(w, "{\"id\":1}\n{\"id\":\"two\"}\n{\"id\":3}\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":"not found"}`)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+
+	client := NewClient()
+
+	collect := func(t *testing.T, path string) ([]int, error) {
+		t.Helper()
+
+		seq, err := StreamJSON[item](context.Background(), client, http.MethodGet, testServer.URL+path, nil)
+		require.NoError(t, err)
+
+		var ids []int
+
+		var streamErr error
+
+		seq(func(v item, err error) bool {
+			if err != nil {
+				streamErr = err
+
+				return false
+			}
+
+			ids = append(ids, v.ID)
+
+			return true
+		})
+
+		return ids, streamErr
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "ndjson", path: "/ndjson", want: []int{1, 2, 3}},
+		{name: "array", path: "/array", want: []int{1, 2, 3}},
+		{name: "empty", path: "/empty"},
+		{name: "truncated", path: "/truncated", want: []int{1}, wantErr: true},
+		{name: "malformed element", path: "/malformed", want: []int{1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ids, err := collect(t, tt.path)
+			assert.Equal(t, tt.want, ids)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("early stop", func(t *testing.T) {
+		t.Parallel()
+
+		seq, err := StreamJSON[item](context.Background(), client, http.MethodGet, testServer.URL+"/array", nil)
+		require.NoError(t, err)
+
+		var calls int
+
+		seq(func(item, error) bool {
+			calls++
+
+			return false
+		})
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("non-2xx", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := StreamJSON[item](context.Background(), client, http.MethodGet, testServer.URL+"/missing", nil)
+
+		var respErr ResponseError
+		require.ErrorAs(t, err, &respErr)
+		assert.Equal(t, http.StatusNotFound, respErr.Response.StatusCode)
+	})
+}
@@ -0,0 +1,160 @@
+package homelogger
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// DropPolicy defines what AsyncWriter does with a message when its queue is full.
+type DropPolicy int
+
+const (
+	// DropNewest discards the message being written.
+	DropNewest DropPolicy = iota
+	// DropOldest discards the oldest queued message to make room for the new one.
+	DropOldest
+	// Block waits until there is room in the queue, so no messages are lost.
+	Block
+)
+
+// WithAsyncWriter writes the logs through the AsyncWriter created with NewAsyncWriter, so a slow output
+// can't stall the callers. The writer stays owned by the caller, which reads Dropped and must Close it
+// on shutdown to flush the queued messages.
+func WithAsyncWriter(w *AsyncWriter) Option {
+	return WithOutput(w)
+}
+
+// AsyncWriter is a zerolog.LevelWriter passing the messages to the underlying writer from a background goroutine.
+// Close must be called on shutdown to flush the queued messages. Fatal and panic messages are never dropped,
+// they are written with all queued messages before WriteLevel returns, as the process is about to exit.
+type AsyncWriter struct {
+	out     zerolog.LevelWriter
+	queue   chan asyncEntry
+	done    chan struct{}
+	policy  DropPolicy
+	dropped atomic.Uint64
+
+	closed bool
+	mutex  sync.RWMutex
+}
+
+type asyncEntry struct {
+	// written is closed once the entry is written, if set
+	written chan struct{}
+	p       []byte
+	level   zerolog.Level
+}
+
+// NewAsyncWriter returns a new AsyncWriter with a queue of bufferSize messages and starts its background goroutine.
+func NewAsyncWriter(out io.Writer, bufferSize int, policy DropPolicy) *AsyncWriter {
+	lw, ok := out.(zerolog.LevelWriter)
+	if !ok {
+		lw = zerolog.LevelWriterAdapter{Writer: out}
+	}
+
+	w := &AsyncWriter{
+		out:    lw,
+		queue:  make(chan asyncEntry, max(bufferSize, 1)),
+		done:   make(chan struct{}),
+		policy: policy,
+	}
+
+	go w.run()
+
+	return w
+}
+
+// Write implements io.Writer.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter, the level is passed to the underlying writer.
+// It never fails: messages that don't fit the queue according to the policy, or are written
+// after Close, are counted as dropped.
+func (w *AsyncWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	// zerolog reuses the buffer after the call returns
+	entry := asyncEntry{p: append([]byte(nil), p...), level: level}
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if w.closed {
+		w.dropped.Add(1)
+
+		return len(p), nil
+	}
+
+	switch {
+	case level == zerolog.FatalLevel || level == zerolog.PanicLevel:
+		entry.written = make(chan struct{})
+		w.queue <- entry
+		<-entry.written
+	case w.policy == Block:
+		w.queue <- entry
+	case w.policy == DropOldest:
+		for {
+			select {
+			case w.queue <- entry:
+				return len(p), nil
+			default:
+			}
+
+			select {
+			case <-w.queue:
+				w.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case w.queue <- entry:
+		default:
+			w.dropped.Add(1)
+		}
+	}
+
+	return len(p), nil
+}
+
+// Dropped returns the number of messages dropped so far.
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close stops accepting messages and waits until the queued ones are written.
+// The underlying writer is not closed.
+func (w *AsyncWriter) Close() error {
+	w.mutex.Lock()
+
+	if w.closed {
+		w.mutex.Unlock()
+
+		return nil
+	}
+
+	w.closed = true
+	close(w.queue)
+	w.mutex.Unlock()
+
+	<-w.done
+
+	return nil
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	for entry := range w.queue {
+		if _, err := w.out.WriteLevel(entry.level, entry.p); err != nil && zerolog.ErrorHandler != nil {
+			zerolog.ErrorHandler(err)
+		}
+
+		if entry.written != nil {
+			close(entry.written)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package homelogger
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedWriter blocks every write until the gate is opened.
+type gatedWriter struct {
+	gate chan struct{}
+	buf  bytes.Buffer
+
+	mutex sync.Mutex
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	<-w.gate
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) String() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.buf.String()
+}
+
+func TestAsyncWriter_DropPolicies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      DropPolicy
+		wantDropped uint64
+		wantLast    string
+	}{
+		{name: "drop newest", policy: DropNewest, wantDropped: 7, wantLast: "msg-2"},
+		{name: "drop oldest", policy: DropOldest, wantDropped: 7, wantLast: "msg-9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &gatedWriter{gate: make(chan struct{})}
+			w := NewAsyncWriter(out, 2, tt.policy)
+
+			// the first message is taken by the background goroutine, which blocks on the gate
+			_, _ = w.Write([]byte("msg-0\n"))
+			require.Eventually(t, func() bool { return len(w.queue) == 0 }, time.Second, time.Millisecond)
+
+			for i := 1; i < 10; i++ {
+				_, _ = w.Write([]byte("msg-" + strconv.Itoa(i) + "\n"))
+			}
+
+			close(out.gate)
+			require.NoError(t, w.Close())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			assert.Len(t, lines, 3)
+			assert.Equal(t, tt.wantLast, lines[len(lines)-1])
+			assert.Equal(t, tt.wantDropped, w.Dropped())
+		})
+	}
+}
+
+func TestAsyncWriter_Block(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	w := NewAsyncWriter(&buf, 1, Block)
+	logger := New(WithOutput(w))
+
+	for i := range 100 {
+		logger.Info().Int("i", i).Msg("")
+	}
+
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, 100, strings.Count(buf.String(), "\n"))
+	assert.Zero(t, w.Dropped())
+
+	_, _ = w.Write([]byte("after close"))
+	assert.Equal(t, uint64(1), w.Dropped())
+}
+
+func TestWithAsyncWriter_KeepsLevels(t *testing.T) {
+	t.Parallel()
+
+	var errs, rest bytes.Buffer
+
+	w := NewAsyncWriter(&levelRouter{
+		writers:  map[zerolog.Level]io.Writer{zerolog.ErrorLevel: &errs},
+		fallback: &rest,
+	}, 10, Block)
+
+	logger := New(WithOutput(w))
+	logger.Error().Msg("failure")
+	logger.Info().Msg("progress")
+
+	require.NoError(t, w.Close())
+
+	assert.Contains(t, errs.String(), "failure")
+	assert.Contains(t, rest.String(), "progress")
+	assert.NotContains(t, rest.String(), "failure")
+}
+
+func TestWithAsyncWriter_FlushesFatal(t *testing.T) {
+	t.Parallel()
+
+	out := &gatedWriter{gate: make(chan struct{})}
+	close(out.gate)
+
+	w := NewAsyncWriter(out, 10, DropNewest)
+
+	logger := New(WithAsyncWriter(w))
+	logger.Info().Msg("queued")
+	logger.WithLevel(zerolog.FatalLevel).Msg("exiting")
+
+	// both messages are written before WithLevel returns, without Close
+	assert.Equal(t, 2, strings.Count(out.String(), "\n"))
+	assert.Contains(t, out.String(), "exiting")
+	assert.Zero(t, w.Dropped())
+
+	require.NoError(t, w.Close())
+}
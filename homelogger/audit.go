@@ -0,0 +1,265 @@
+package homelogger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrAuditTampered is returned by VerifyAuditLog when the hash chain of the audit log is broken.
+	ErrAuditTampered = errors.New("audit log has been tampered with")
+	// ErrAuditBroken is returned by AuditLogger.Log after a failed write couldn't be removed from the file.
+	ErrAuditBroken = errors.New("audit log is broken by a failed write")
+)
+
+// AuditEntry is a single record of the audit log.
+// Hash is the SHA-256 of the entry without the hash, PrevHash links it to the previous entry.
+type AuditEntry struct {
+	Seq      uint64          `json:"seq"`
+	Time     string          `json:"time"`
+	Event    string          `json:"event"`
+	Fields   json.RawMessage `json:"fields,omitempty"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash,omitempty"`
+}
+
+// AuditLogger writes security-relevant events into an append-only file of JSON lines.
+// Unlike the zerolog output, every write is synced to the disk before Log returns and the errors are reported
+// to the caller. Each entry carries a sequence number and the hash of the previous entry,
+// so removed or modified entries are detected by VerifyAuditLog.
+type AuditLogger struct {
+	file auditFile
+	now  func() time.Time
+
+	seq      uint64
+	lastHash string
+	// broken is set when a failed write couldn't be truncated, further entries would break the chain
+	broken error
+
+	mutex sync.Mutex
+}
+
+// auditFile is the part of *os.File used by AuditLogger.
+type auditFile interface {
+	io.WriteSeeker
+	io.Closer
+	Sync() error
+	Truncate(size int64) error
+}
+
+// OpenAuditLogger opens the audit log at path, continuing the sequence and the hash chain of the existing entries.
+func OpenAuditLogger(path string) (*AuditLogger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	a := &AuditLogger{file: file, now: time.Now}
+
+	last, err := lastAuditEntry(path)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, err
+	}
+
+	if last != nil {
+		a.seq = last.Seq
+		a.lastHash = last.Hash
+	}
+
+	return a, nil
+}
+
+// Log appends the event with the fields and syncs the file.
+// A failed write is truncated from the file, so it can be retried. If that fails as well,
+// ErrAuditBroken is returned by this and all further calls, as the chain can't be continued.
+func (a *AuditLogger) Log(event string, fields map[string]any) error {
+	entry := AuditEntry{Event: event}
+
+	if len(fields) > 0 {
+		raw, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit fields: %w", err)
+		}
+
+		entry.Fields = raw
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.file == nil {
+		return os.ErrClosed
+	}
+
+	if a.broken != nil {
+		return a.broken
+	}
+
+	entry.Seq = a.seq + 1
+	entry.Time = a.now().UTC().Format(time.RFC3339Nano)
+	entry.PrevHash = a.lastHash
+
+	hash, err := entry.hash()
+	if err != nil {
+		return err
+	}
+
+	entry.Hash = hash
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	offset, err := a.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("failed to seek audit log: %w", err)
+	}
+
+	if err = a.write(line); err != nil {
+		a.rollback(offset, err)
+
+		return err
+	}
+
+	a.seq = entry.Seq
+	a.lastHash = entry.Hash
+
+	return nil
+}
+
+func (a *AuditLogger) write(line []byte) error {
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+
+	if err := a.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+
+	return nil
+}
+
+// rollback removes a partially written or not synced entry from the end of the file,
+// the truncation is synced together with the next entry.
+func (a *AuditLogger) rollback(offset int64, cause error) {
+	if err := a.file.Truncate(offset); err != nil {
+		a.broken = fmt.Errorf("%w: %w, failed to truncate it: %w", ErrAuditBroken, cause, err)
+	}
+}
+
+// Close closes the audit log file.
+func (a *AuditLogger) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+
+	err := a.file.Close()
+	a.file = nil
+
+	return err
+}
+
+// VerifyAuditLog checks the sequence numbers and the hash chain of the audit log at path
+// and returns ErrAuditTampered describing the first broken entry.
+func VerifyAuditLog(path string) error {
+	file, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var prev AuditEntry
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		var entry AuditEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("%w: line %d is not a valid entry: %w", ErrAuditTampered, line, err)
+		}
+
+		if entry.Seq != prev.Seq+1 {
+			return fmt.Errorf("%w: line %d has sequence %d, expected %d", ErrAuditTampered, line, entry.Seq, prev.Seq+1)
+		}
+
+		if entry.PrevHash != prev.Hash {
+			return fmt.Errorf("%w: entry %d doesn't link to the previous entry", ErrAuditTampered, entry.Seq)
+		}
+
+		hash, err := entry.hash()
+		if err != nil {
+			return err
+		}
+
+		if hash != entry.Hash {
+			return fmt.Errorf("%w: entry %d hash mismatch", ErrAuditTampered, entry.Seq)
+		}
+
+		prev = entry
+	}
+
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return nil
+}
+
+func (e AuditEntry) hash() (string, error) {
+	e.Hash = ""
+
+	// re-encoding compacts the fields, so the hash doesn't depend on how the line was formatted
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// lastAuditEntry returns the last entry of the file or nil if the file is empty.
+func lastAuditEntry(path string) (*AuditEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		data = data[i+1:]
+	}
+
+	var entry AuditEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("%w: last entry is not valid: %w", ErrAuditTampered, err)
+	}
+
+	return &entry, nil
+}
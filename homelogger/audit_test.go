@@ -0,0 +1,163 @@
+package homelogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogger(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+
+	audit, err := OpenAuditLogger(path)
+	require.NoError(t, err)
+
+	audit.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, audit.Log("login", map[string]any{"user": "alice"}))
+	require.NoError(t, audit.Log("logout", nil))
+	require.NoError(t, audit.Close())
+	require.ErrorIs(t, audit.Log("late", nil), os.ErrClosed)
+
+	// reopening continues the chain
+	audit, err = OpenAuditLogger(path)
+	require.NoError(t, err)
+	require.NoError(t, audit.Log("login", map[string]any{"user": "bob", "attempt": 2}))
+	require.NoError(t, audit.Close())
+
+	require.NoError(t, VerifyAuditLog(path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"seq":1`)
+	assert.Contains(t, lines[0], `"fields":{"user":"alice"}`)
+	assert.Contains(t, lines[0], `"time":"2024-01-01T00:00:00Z"`)
+	assert.Contains(t, lines[2], `"seq":3`)
+}
+
+func TestVerifyAuditLog_Tampered(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+	}{
+		{
+			name: "modified fields",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], "alice", "mallory", 1)
+				return lines
+			},
+		},
+		{
+			name: "removed entry",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+		},
+		{
+			name: "reordered entries",
+			tamper: func(lines []string) []string {
+				lines[0], lines[1] = lines[1], lines[0]
+				return lines
+			},
+		},
+		{
+			name: "garbage line",
+			tamper: func(lines []string) []string {
+				return append(lines, "not json")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "audit.log")
+
+			audit, err := OpenAuditLogger(path)
+			require.NoError(t, err)
+
+			for _, user := range []string{"bob", "alice", "carol"} {
+				require.NoError(t, audit.Log("login", map[string]any{"user": user}))
+			}
+
+			require.NoError(t, audit.Close())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			lines := tt.tamper(strings.Split(strings.TrimSpace(string(content)), "\n"))
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+
+			require.ErrorIs(t, VerifyAuditLog(path), ErrAuditTampered)
+		})
+	}
+}
+
+// failingAuditFile fails Sync and optionally Truncate of the wrapped file.
+type failingAuditFile struct {
+	*os.File
+	failSync     bool
+	failTruncate bool
+}
+
+func (f *failingAuditFile) Sync() error {
+	if f.failSync {
+		return errors.New("sync failed")
+	}
+
+	return f.File.Sync()
+}
+
+func (f *failingAuditFile) Truncate(size int64) error {
+	if f.failTruncate {
+		return errors.New("truncate failed")
+	}
+
+	return f.File.Truncate(size)
+}
+
+func TestAuditLogger_FailedWrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	audit, err := OpenAuditLogger(path)
+	require.NoError(t, err)
+
+	file := &failingAuditFile{File: audit.file.(*os.File)} //nolint:forcetypeassert
+	audit.file = file
+
+	require.NoError(t, audit.Log("first", nil))
+
+	file.failSync = true
+	require.Error(t, audit.Log("not synced", nil))
+
+	file.failSync = false
+	require.NoError(t, audit.Log("second", nil), "the failed entry is removed, so the chain continues")
+	require.NoError(t, VerifyAuditLog(path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "not synced")
+
+	file.failSync, file.failTruncate = true, true
+	require.Error(t, audit.Log("not synced", nil))
+
+	file.failSync, file.failTruncate = false, false
+	require.ErrorIs(t, audit.Log("third", nil), ErrAuditBroken)
+	require.NoError(t, audit.Close())
+}
@@ -0,0 +1,53 @@
+package homelogger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type (
+	loggerCtxKey struct{}
+	fieldsCtxKey struct{}
+)
+
+// ToContext returns a copy of ctx that carries the logger.
+func ToContext(ctx context.Context, logger *zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx enriched with the fields added by WithContextFields.
+// If there is no logger in ctx, a no-op logger is returned.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	return FromContextOr(ctx, NewNoOp())
+}
+
+// FromContextOr returns the logger stored in ctx enriched with the fields added by WithContextFields.
+// If there is no logger in ctx, the fallback logger is enriched instead.
+func FromContextOr(ctx context.Context, fallback *zerolog.Logger) *zerolog.Logger {
+	logger, ok := ctx.Value(loggerCtxKey{}).(*zerolog.Logger)
+	if !ok || logger == nil {
+		logger = fallback
+	}
+
+	fields, _ := ctx.Value(fieldsCtxKey{}).([]any)
+	if len(fields) == 0 {
+		return logger
+	}
+
+	l := logger.With().Fields(fields).Logger()
+
+	return &l
+}
+
+// WithContextFields returns a copy of ctx with additional key-value pairs,
+// e.g. request or user IDs, that are attached to every logger obtained from the context.
+func WithContextFields(ctx context.Context, kv ...any) context.Context {
+	existing, _ := ctx.Value(fieldsCtxKey{}).([]any)
+
+	fields := make([]any, 0, len(existing)+len(kv))
+	fields = append(fields, existing...)
+	fields = append(fields, kv...)
+
+	return context.WithValue(ctx, fieldsCtxKey{}, fields)
+}
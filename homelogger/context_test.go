@@ -0,0 +1,46 @@
+package homelogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	ctx := WithContextFields(context.Background(), "request_id", "42")
+	ctx = ToContext(ctx, New(WithOutput(&buf)))
+	ctx = WithContextFields(ctx, "user_id", 7)
+
+	FromContext(ctx).Info().Msg("hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "42", entry["request_id"])
+	assert.InDelta(t, 7, entry["user_id"], 0)
+	assert.Equal(t, "hello", entry["message"])
+}
+
+func TestFromContextOr(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	fallback := New(WithOutput(&buf))
+
+	assert.Same(t, fallback, FromContextOr(context.Background(), fallback))
+
+	FromContextOr(WithContextFields(context.Background(), "key", "value"), fallback).Info().Msg("")
+	assert.Contains(t, buf.String(), `"key":"value"`)
+
+	// no logger in context falls back to no-op
+	FromContext(context.Background()).Info().Msg("dropped")
+}
@@ -0,0 +1,60 @@
+package homelogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+)
+
+// developmentTimeFormat is the human-readable timestamp format used by NewDevelopment.
+const developmentTimeFormat = "15:04:05.000"
+
+// NewDevelopment returns a logger for local development, the counterpart of NewDefault:
+// colored human-readable output with short timestamps, the application name first and short caller paths.
+func NewDevelopment(appName string) *zerolog.Logger {
+	return newDevelopment(appName, os.Stdout)
+}
+
+func newDevelopment(appName string, out io.Writer) *zerolog.Logger {
+	options := []Option{
+		WithLevel(zerolog.DebugLevel),
+		WithOutput(newDevelopmentWriter(out)),
+		WithCaller(),
+		WithTime(),
+		WithStack(),
+		WithApplicationName(appName),
+	}
+
+	return New(options...)
+}
+
+func newDevelopmentWriter(out io.Writer) zerolog.ConsoleWriter {
+	return zerolog.ConsoleWriter{
+		Out:        out,
+		TimeFormat: developmentTimeFormat,
+		PartsOrder: []string{
+			zerolog.TimestampFieldName,
+			zerolog.LevelFieldName,
+			applicationKey,
+			zerolog.CallerFieldName,
+			zerolog.MessageFieldName,
+		},
+		FieldsExclude: []string{applicationKey},
+		FormatCaller:  formatShortCaller,
+	}
+}
+
+// formatShortCaller trims the caller to the package directory and file, e.g. "homelogger/logger.go:12".
+func formatShortCaller(i any) string {
+	caller, ok := i.(string)
+	if !ok || caller == "" {
+		return ""
+	}
+
+	dir, file := filepath.Split(caller)
+
+	return fmt.Sprintf("%s >", filepath.Join(filepath.Base(dir), file))
+}
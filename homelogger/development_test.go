@@ -0,0 +1,35 @@
+package homelogger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDevelopment(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := newDevelopment("billing", &buf)
+	logger.Debug().Str("user", "bob").Msg("hello")
+
+	out := buf.String()
+
+	assert.Contains(t, out, "DBG")
+	assert.Contains(t, out, "billing")
+	assert.Contains(t, out, "homelogger/development_test.go:")
+	assert.Contains(t, out, "hello")
+	assert.Contains(t, out, "user=")
+	assert.NotContains(t, out, "application=")
+	assert.Less(t, strings.Index(out, "billing"), strings.Index(out, "hello"))
+}
+
+func TestFormatShortCaller(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "homelogger/logger.go:12 >", formatShortCaller("/src/home-lib/homelogger/logger.go:12"))
+	assert.Empty(t, formatShortCaller(nil))
+}
@@ -0,0 +1,106 @@
+package homelogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	envLevel   = "LOG_LEVEL"
+	envFormat  = "LOG_FORMAT"
+	envCaller  = "LOG_CALLER"
+	envTime    = "LOG_TIME"
+	envStack   = "LOG_STACK"
+	envAppName = "LOG_APP_NAME"
+
+	formatJSON    = "json"
+	formatConsole = "console"
+)
+
+// NewFromEnv builds a logger from environment variables, each name is prefixed with "<prefix>_" if prefix is set:
+//
+//	LOG_LEVEL     trace, debug, info (default), warn, error, fatal, panic, disabled
+//	LOG_FORMAT    json (default) or console
+//	LOG_CALLER    add the caller to messages (default false)
+//	LOG_TIME      add the timestamp to messages (default true)
+//	LOG_STACK     add stack traces to errors (default false)
+//	LOG_APP_NAME  application name added to messages
+func NewFromEnv(prefix string) (*zerolog.Logger, error) {
+	return newFromEnv(prefix, os.LookupEnv, os.Stdout)
+}
+
+func newFromEnv(prefix string, lookup func(string) (string, bool), out io.Writer) (*zerolog.Logger, error) {
+	get := func(name string) (string, bool) {
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		v, ok := lookup(name)
+
+		return strings.TrimSpace(v), ok && strings.TrimSpace(v) != ""
+	}
+
+	getBool := func(name string, def bool) (bool, error) {
+		v, ok := get(name)
+		if !ok {
+			return def, nil
+		}
+
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s value %q: %w", name, v, err)
+		}
+
+		return b, nil
+	}
+
+	level := zerolog.InfoLevel
+
+	if v, ok := get(envLevel); ok {
+		var err error
+		if level, err = zerolog.ParseLevel(strings.ToLower(v)); err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", envLevel, v, err)
+		}
+	}
+
+	options := []Option{WithLevel(level)}
+
+	switch format, _ := get(envFormat); strings.ToLower(format) {
+	case "", formatJSON:
+		options = append(options, WithOutput(out))
+	case formatConsole:
+		options = append(options, WithOutput(zerolog.ConsoleWriter{Out: out}))
+	default:
+		return nil, fmt.Errorf("invalid %s value %q: expected %s or %s", envFormat, format, formatJSON, formatConsole)
+	}
+
+	for _, flag := range []struct {
+		name   string
+		option Option
+		def    bool
+	}{
+		{name: envCaller, option: WithCaller()},
+		{name: envTime, option: WithTime(), def: true},
+		{name: envStack, option: WithStack()},
+	} {
+		enabled, err := getBool(flag.name, flag.def)
+		if err != nil {
+			return nil, err
+		}
+
+		if enabled {
+			options = append(options, flag.option)
+		}
+	}
+
+	if appName, ok := get(envAppName); ok {
+		options = append(options, WithApplicationName(appName))
+	}
+
+	return New(options...), nil
+}
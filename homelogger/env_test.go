@@ -0,0 +1,90 @@
+package homelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	t.Parallel()
+
+	lookup := func(env map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			v, ok := env[key]
+			return v, ok
+		}
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger, err := newFromEnv("", lookup(nil), &buf)
+		require.NoError(t, err)
+		assert.Equal(t, zerolog.InfoLevel, logger.GetLevel())
+
+		logger.Info().Msg("hello")
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Contains(t, entry, "time")
+		assert.NotContains(t, entry, "caller")
+	})
+
+	t.Run("Prefixed variables", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger, err := newFromEnv("SVC", lookup(map[string]string{
+			"SVC_LOG_LEVEL":    "DEBUG",
+			"SVC_LOG_CALLER":   "true",
+			"SVC_LOG_TIME":     "false",
+			"SVC_LOG_APP_NAME": "svc",
+			"LOG_LEVEL":        "error",
+		}), &buf)
+		require.NoError(t, err)
+		assert.Equal(t, zerolog.DebugLevel, logger.GetLevel())
+
+		logger.Debug().Msg("hello")
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, "svc", entry["application"])
+		assert.Contains(t, entry, "caller")
+		assert.NotContains(t, entry, "time")
+	})
+
+	t.Run("Console format", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger, err := newFromEnv("", lookup(map[string]string{"LOG_FORMAT": "console"}), &buf)
+		require.NoError(t, err)
+
+		logger.Info().Msg("hello")
+		assert.Contains(t, buf.String(), "INF")
+		assert.Contains(t, buf.String(), "hello")
+		assert.False(t, json.Valid(buf.Bytes()))
+	})
+
+	t.Run("Invalid values", func(t *testing.T) {
+		t.Parallel()
+
+		for _, env := range []map[string]string{
+			{"LOG_LEVEL": "loud"},
+			{"LOG_FORMAT": "xml"},
+			{"LOG_CALLER": "maybe"},
+		} {
+			_, err := newFromEnv("", lookup(env), &bytes.Buffer{})
+			assert.Error(t, err, env)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package homelogger
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/rs/zerolog"
+)
+
+// Field names shared by the field helpers, so that all applications log with the same schema.
+const (
+	ServiceNameKey    = "service.name"
+	ServiceVersionKey = "service.version"
+	EnvironmentKey    = "service.environment"
+	HostKey           = "host"
+	PIDKey            = "pid"
+	GoVersionKey      = "go_version"
+	GoOSKey           = "go_os"
+	GoArchKey         = "go_arch"
+)
+
+// WithService adds the service name, version and environment to the log messages.
+// Empty values are omitted.
+func WithService(name, version, env string) Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		ctx := logger.With()
+
+		for _, f := range []struct{ key, value string }{
+			{ServiceNameKey, name},
+			{ServiceVersionKey, version},
+			{EnvironmentKey, env},
+		} {
+			if f.value != "" {
+				ctx = ctx.Str(f.key, f.value)
+			}
+		}
+
+		l := ctx.Logger()
+		*logger = l
+	})
+}
+
+// WithHostInfo adds the host name and the process id to the log messages.
+func WithHostInfo() Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		ctx := logger.With().Int(PIDKey, os.Getpid())
+
+		if host, err := os.Hostname(); err == nil {
+			ctx = ctx.Str(HostKey, host)
+		}
+
+		l := ctx.Logger()
+		*logger = l
+	})
+}
+
+// WithGoRuntime adds the Go version, OS and architecture to the log messages.
+func WithGoRuntime() Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		l := logger.With().
+			Str(GoVersionKey, runtime.Version()).
+			Str(GoOSKey, runtime.GOOS).
+			Str(GoArchKey, runtime.GOARCH).
+			Logger()
+		*logger = l
+	})
+}
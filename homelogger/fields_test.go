@@ -0,0 +1,36 @@
+package homelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldHelpers(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := New(WithOutput(&buf), WithService("billing", "1.2.3", ""), WithHostInfo(), WithGoRuntime())
+	logger.Info().Msg("hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	host, err := os.Hostname()
+	require.NoError(t, err)
+
+	assert.Equal(t, "billing", entry[ServiceNameKey])
+	assert.Equal(t, "1.2.3", entry[ServiceVersionKey])
+	assert.NotContains(t, entry, EnvironmentKey)
+	assert.Equal(t, host, entry[HostKey])
+	assert.InDelta(t, os.Getpid(), entry[PIDKey], 0)
+	assert.Equal(t, runtime.Version(), entry[GoVersionKey])
+	assert.Equal(t, runtime.GOOS, entry[GoOSKey])
+	assert.Equal(t, runtime.GOARCH, entry[GoArchKey])
+}
@@ -0,0 +1,142 @@
+package homelogger
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// WithHook adds hooks that are run for every message, e.g. to enrich or forward it.
+func WithHook(hooks ...zerolog.Hook) Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		l := logger.Hook(hooks...)
+		*logger = l
+	})
+}
+
+// LevelCounter is a hook counting the logged messages per level, e.g. to expose them as metrics.
+type LevelCounter struct {
+	counts map[zerolog.Level]uint64
+
+	mutex sync.Mutex
+}
+
+// NewLevelCounter returns a new LevelCounter.
+func NewLevelCounter() *LevelCounter {
+	return &LevelCounter{counts: make(map[zerolog.Level]uint64)}
+}
+
+// Run implements zerolog.Hook.
+func (c *LevelCounter) Run(_ *zerolog.Event, level zerolog.Level, _ string) {
+	c.mutex.Lock()
+	c.counts[level]++
+	c.mutex.Unlock()
+}
+
+// Count returns the number of messages logged with the level.
+func (c *LevelCounter) Count(level zerolog.Level) uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.counts[level]
+}
+
+// Counts returns a snapshot of the counts of all levels.
+func (c *LevelCounter) Counts() map[zerolog.Level]uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	counts := make(map[zerolog.Level]uint64, len(c.counts))
+	for level, n := range c.counts {
+		counts[level] = n
+	}
+
+	return counts
+}
+
+// TraceIDHook adds the trace ID returned by extract under the key to messages logged with a context,
+// i.e. via Event.Ctx or Logger.WithContext. Nothing is added if the ID is empty.
+func TraceIDHook(key string, extract func(ctx context.Context) string) zerolog.Hook {
+	return zerolog.HookFunc(func(e *zerolog.Event, _ zerolog.Level, _ string) {
+		if id := extract(e.GetCtx()); id != "" {
+			e.Str(key, id)
+		}
+	})
+}
+
+// SeverityHook adds the level mapped by severity under the key, for log pipelines expecting
+// their own level names.
+func SeverityHook(key string, severity func(level zerolog.Level) string) zerolog.Hook {
+	return zerolog.HookFunc(func(e *zerolog.Event, level zerolog.Level, _ string) {
+		if s := severity(level); s != "" {
+			e.Str(key, s)
+		}
+	})
+}
+
+// GCPSeverityHook adds the "severity" field understood by Google Cloud Logging.
+func GCPSeverityHook() zerolog.Hook {
+	return SeverityHook("severity", func(level zerolog.Level) string {
+		switch level { //nolint:exhaustive
+		case zerolog.TraceLevel, zerolog.DebugLevel:
+			return "DEBUG"
+		case zerolog.InfoLevel:
+			return "INFO"
+		case zerolog.WarnLevel:
+			return "WARNING"
+		case zerolog.ErrorLevel:
+			return "ERROR"
+		case zerolog.FatalLevel:
+			return "CRITICAL"
+		case zerolog.PanicLevel:
+			return "ALERT"
+		default:
+			return "DEFAULT"
+		}
+	})
+}
+
+// DatadogSeverityHook adds the "status" field understood by Datadog.
+func DatadogSeverityHook() zerolog.Hook {
+	return SeverityHook("status", func(level zerolog.Level) string {
+		switch level { //nolint:exhaustive
+		case zerolog.TraceLevel, zerolog.DebugLevel:
+			return "debug"
+		case zerolog.InfoLevel:
+			return "info"
+		case zerolog.WarnLevel:
+			return "warning"
+		case zerolog.ErrorLevel:
+			return "error"
+		case zerolog.FatalLevel:
+			return "critical"
+		case zerolog.PanicLevel:
+			return "emergency"
+		default:
+			return ""
+		}
+	})
+}
+
+// CloudWatchSeverityHook adds the "severity" field with the upper-case level name, e.g. "WARN".
+func CloudWatchSeverityHook() zerolog.Hook {
+	return SeverityHook("severity", func(level zerolog.Level) string {
+		switch level { //nolint:exhaustive
+		case zerolog.TraceLevel:
+			return "TRACE"
+		case zerolog.DebugLevel:
+			return "DEBUG"
+		case zerolog.InfoLevel:
+			return "INFO"
+		case zerolog.WarnLevel:
+			return "WARN"
+		case zerolog.ErrorLevel:
+			return "ERROR"
+		case zerolog.FatalLevel, zerolog.PanicLevel:
+			return "FATAL"
+		default:
+			return ""
+		}
+	})
+}
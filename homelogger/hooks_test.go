@@ -0,0 +1,82 @@
+package homelogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceIDCtxKey struct{}
+
+func TestLevelCounter(t *testing.T) {
+	t.Parallel()
+
+	counter := NewLevelCounter()
+	logger := New(WithOutput(&bytes.Buffer{}), WithHook(counter))
+
+	logger.Info().Msg("one")
+	logger.Info().Msg("two")
+	logger.Error().Msg("three")
+
+	assert.Equal(t, uint64(2), counter.Count(zerolog.InfoLevel))
+	assert.Equal(t, uint64(1), counter.Count(zerolog.ErrorLevel))
+	assert.Equal(t, uint64(0), counter.Count(zerolog.WarnLevel))
+	assert.Equal(t, map[zerolog.Level]uint64{zerolog.InfoLevel: 2, zerolog.ErrorLevel: 1}, counter.Counts())
+}
+
+func TestTraceIDHook(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	hook := TraceIDHook("trace_id", func(ctx context.Context) string {
+		id, _ := ctx.Value(traceIDCtxKey{}).(string)
+		return id
+	})
+	logger := New(WithOutput(&buf), WithHook(hook))
+
+	logger.Info().Ctx(context.WithValue(context.Background(), traceIDCtxKey{}, "abc")).Msg("traced")
+	logger.Info().Msg("untraced")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), `"trace_id":"abc"`)
+	assert.NotContains(t, string(lines[1]), "trace_id")
+}
+
+func TestSeverityHooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		hook  zerolog.Hook
+		key   string
+		level zerolog.Level
+		want  string
+	}{
+		{name: "GCP warning", hook: GCPSeverityHook(), key: "severity", level: zerolog.WarnLevel, want: "WARNING"},
+		{name: "GCP debug", hook: GCPSeverityHook(), key: "severity", level: zerolog.TraceLevel, want: "DEBUG"},
+		{name: "Datadog error", hook: DatadogSeverityHook(), key: "status", level: zerolog.ErrorLevel, want: "error"},
+		{name: "Datadog info", hook: DatadogSeverityHook(), key: "status", level: zerolog.InfoLevel, want: "info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			logger := New(WithOutput(&buf), WithLevel(zerolog.TraceLevel), WithHook(tt.hook))
+			logger.WithLevel(tt.level).Msg("hello")
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.want, entry[tt.key])
+		})
+	}
+}
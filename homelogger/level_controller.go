@@ -0,0 +1,86 @@
+package homelogger
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// LevelController holds a log level that can be changed at runtime.
+// It implements http.Handler: GET returns the current level, PUT or POST with {"level":"debug"}
+// or ?level=debug changes it.
+type LevelController struct {
+	level atomic.Int32
+}
+
+// NewLevelController returns a LevelController with the initial level.
+func NewLevelController(level zerolog.Level) *LevelController {
+	c := &LevelController{}
+	c.SetLevel(level)
+
+	return c
+}
+
+// Level returns the current level.
+func (c *LevelController) Level() zerolog.Level {
+	return zerolog.Level(c.level.Load())
+}
+
+// SetLevel changes the level of all loggers using the controller.
+func (c *LevelController) SetLevel(level zerolog.Level) {
+	c.level.Store(int32(level))
+}
+
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+// ServeHTTP implements http.Handler.
+func (c *LevelController) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var payload levelPayload
+
+		if payload.Level = req.URL.Query().Get("level"); payload.Level == "" {
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		level, err := zerolog.ParseLevel(payload.Level)
+		if err != nil || payload.Level == "" {
+			http.Error(w, "invalid level: "+payload.Level, http.StatusBadRequest)
+			return
+		}
+
+		c.SetLevel(level)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(levelPayload{Level: c.Level().String()})
+}
+
+// Run implements zerolog.Hook, it discards messages below the current level.
+func (c *LevelController) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	if level < c.Level() && level != zerolog.NoLevel {
+		e.Discard()
+	}
+}
+
+// WithDynamicLevel makes the logger level controlled by the LevelController at runtime.
+// It overrides the level set by WithLevel, so it should not be combined with it.
+func WithDynamicLevel(controller *LevelController) Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		l := logger.Level(zerolog.TraceLevel).Hook(controller)
+		*logger = l
+	})
+}
@@ -0,0 +1,98 @@
+package homelogger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDynamicLevel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	controller := NewLevelController(zerolog.InfoLevel)
+	logger := New(WithOutput(&buf), WithDynamicLevel(controller))
+
+	logger.Debug().Msg("hidden")
+	controller.SetLevel(zerolog.DebugLevel)
+	logger.Debug().Msg("visible")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "visible")
+}
+
+func TestLevelController_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	controller := NewLevelController(zerolog.InfoLevel)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantLevel  zerolog.Level
+	}{
+		{
+			name:       "Get level",
+			method:     http.MethodGet,
+			target:     "/",
+			wantStatus: http.StatusOK,
+			wantLevel:  zerolog.InfoLevel,
+		},
+		{
+			name:       "Set level with JSON",
+			method:     http.MethodPut,
+			target:     "/",
+			body:       `{"level":"debug"}`,
+			wantStatus: http.StatusOK,
+			wantLevel:  zerolog.DebugLevel,
+		},
+		{
+			name:       "Set level with query",
+			method:     http.MethodPost,
+			target:     "/?level=warn",
+			wantStatus: http.StatusOK,
+			wantLevel:  zerolog.WarnLevel,
+		},
+		{
+			name:       "Invalid level",
+			method:     http.MethodPut,
+			target:     "/",
+			body:       `{"level":"loud"}`,
+			wantStatus: http.StatusBadRequest,
+			wantLevel:  zerolog.WarnLevel,
+		},
+		{
+			name:       "Method not allowed",
+			method:     http.MethodDelete,
+			target:     "/",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantLevel:  zerolog.WarnLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+
+			controller.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLevel, controller.Level())
+
+			if tt.wantStatus == http.StatusOK {
+				assert.JSONEq(t, `{"level":"`+tt.wantLevel.String()+`"}`, rec.Body.String())
+			}
+		})
+	}
+}
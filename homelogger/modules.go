@@ -0,0 +1,123 @@
+package homelogger
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// ModuleKey is the field holding the module name of loggers derived with Named.
+const ModuleKey = "module"
+
+// ModuleLevels holds the level overrides of the modules of a logger, see WithModuleLevels.
+// A module override also applies to its submodules, e.g. "homehttp" applies to "homehttp.retry".
+type ModuleLevels struct {
+	levels map[string]zerolog.Level
+
+	mutex sync.RWMutex
+}
+
+type moduleLevelsKey struct{}
+
+// WithModuleLevels overrides the levels of the modules of the logger, e.g. {"homehttp": zerolog.DebugLevel}.
+// The overrides apply to the loggers derived with Named, they are inherited by the children of Named loggers
+// and can be changed at runtime with the handle returned by ModuleLevelsOf.
+func WithModuleLevels(levels map[string]zerolog.Level) Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		m := &ModuleLevels{levels: make(map[string]zerolog.Level, len(levels))}
+		for module, level := range levels {
+			m.levels[module] = level
+		}
+
+		l := logger.With().Ctx(context.WithValue(loggerContext(logger), moduleLevelsKey{}, m)).Logger()
+		*logger = l
+	})
+}
+
+// ModuleLevelsOf returns the module level overrides of the logger, set by WithModuleLevels
+// on the logger or on the logger it was derived from. The second return value is false if there are none.
+func ModuleLevelsOf(logger *zerolog.Logger) (*ModuleLevels, bool) {
+	m, ok := loggerContext(logger).Value(moduleLevelsKey{}).(*ModuleLevels)
+
+	return m, ok
+}
+
+// loggerContext returns the context.Context of the logger, zerolog doesn't expose it,
+// so it is read from an event that is never written.
+func loggerContext(logger *zerolog.Logger) context.Context {
+	probe := logger.Level(zerolog.TraceLevel).Sample(nil)
+
+	return probe.Log().GetCtx()
+}
+
+// SetLevel overrides the level of the module and its submodules.
+func (m *ModuleLevels) SetLevel(module string, level zerolog.Level) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.levels[module] = level
+}
+
+// ResetLevel removes the level override of the module, its loggers use the level of their parent again.
+func (m *ModuleLevels) ResetLevel(module string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.levels, module)
+}
+
+// Level returns the level override of the module or of its closest parent module.
+func (m *ModuleLevels) Level(module string) (zerolog.Level, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for {
+		if level, ok := m.levels[module]; ok {
+			return level, true
+		}
+
+		i := strings.LastIndexByte(module, '.')
+		if i < 0 {
+			return zerolog.NoLevel, false
+		}
+
+		module = module[:i]
+	}
+}
+
+// Named returns a child logger of the module, adding the module name under ModuleKey.
+// Its level is the module override of WithModuleLevels if set, otherwise the level of the parent logger
+// at the time of the call. Overrides can lower the level below the parent one,
+// but not below a level enforced by WithDynamicLevel.
+func Named(logger *zerolog.Logger, module string) *zerolog.Logger {
+	filter := moduleFilter{module: module, fallback: logger.GetLevel()}
+	filter.levels, _ = ModuleLevelsOf(logger)
+
+	l := logger.With().Str(ModuleKey, module).Logger().Level(zerolog.TraceLevel).Hook(filter)
+
+	return &l
+}
+
+type moduleFilter struct {
+	levels   *ModuleLevels
+	module   string
+	fallback zerolog.Level
+}
+
+// Run implements zerolog.Hook, it discards messages below the current level of the module.
+func (f moduleFilter) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	minLevel, ok := zerolog.NoLevel, false
+	if f.levels != nil {
+		minLevel, ok = f.levels.Level(f.module)
+	}
+
+	if !ok {
+		minLevel = f.fallback
+	}
+
+	if level < minLevel && level != zerolog.NoLevel {
+		e.Discard()
+	}
+}
@@ -0,0 +1,91 @@
+package homelogger
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamed(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := New(
+		WithOutput(&buf),
+		WithLevel(zerolog.InfoLevel),
+		WithModuleLevels(map[string]zerolog.Level{"http": zerolog.DebugLevel}),
+	)
+
+	httpLogger := Named(logger, "http")
+	retryLogger := Named(logger, "http.retry")
+	dbLogger := Named(logger, "db")
+
+	logger.Debug().Msg("root debug")
+	httpLogger.Debug().Msg("http debug")
+	retryLogger.Debug().Msg("retry debug")
+	dbLogger.Debug().Msg("db debug")
+	dbLogger.Info().Msg("db info")
+
+	out := buf.String()
+	assert.NotContains(t, out, "root debug")
+	assert.Contains(t, out, `"module":"http","message":"http debug"`)
+	assert.Contains(t, out, "retry debug")
+	assert.NotContains(t, out, "db debug")
+	assert.Contains(t, out, "db info")
+
+	levels, ok := ModuleLevelsOf(dbLogger)
+	require.True(t, ok, "named loggers inherit the overrides")
+
+	buf.Reset()
+	levels.SetLevel("http.retry", zerolog.WarnLevel)
+	levels.SetLevel("db", zerolog.DebugLevel)
+
+	retryLogger.Info().Msg("retry info")
+	dbLogger.Debug().Msg("db debug")
+
+	assert.NotContains(t, buf.String(), "retry info")
+	assert.Contains(t, buf.String(), "db debug")
+
+	buf.Reset()
+	levels.ResetLevel("db")
+
+	dbLogger.Debug().Msg("db debug")
+	assert.Empty(t, buf.String())
+}
+
+func TestModuleLevelsArePerLogger(t *testing.T) {
+	t.Parallel()
+
+	var first, second bytes.Buffer
+
+	Named(New(WithOutput(&first), WithLevel(zerolog.InfoLevel), WithModuleLevels(map[string]zerolog.Level{"db": zerolog.DebugLevel})), "db").
+		Debug().Msg("first")
+	Named(New(WithOutput(&second), WithLevel(zerolog.InfoLevel)), "db").Debug().Msg("second")
+
+	assert.Contains(t, first.String(), "first")
+	assert.Empty(t, second.String())
+
+	_, ok := ModuleLevelsOf(New())
+	assert.False(t, ok)
+}
+
+func TestModuleLevels_Level(t *testing.T) {
+	t.Parallel()
+
+	logger := New(WithModuleLevels(map[string]zerolog.Level{"module": zerolog.ErrorLevel}), WithOutput(io.Discard))
+
+	levels, ok := ModuleLevelsOf(logger)
+	require.True(t, ok)
+
+	level, ok := levels.Level("module.sub.deep")
+	assert.True(t, ok)
+	assert.Equal(t, zerolog.ErrorLevel, level)
+
+	_, ok = levels.Level("module-other")
+	assert.False(t, ok)
+}
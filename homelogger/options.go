@@ -36,7 +36,8 @@ func WithCaller() Option {
 // WithOutput sets the output writer.
 func WithOutput(output io.Writer) Option {
 	return optionFn(func(logger *zerolog.Logger) {
-		l := logger.Output(output)
+		// Output doesn't keep the context, which holds the module levels
+		l := logger.Output(output).With().Ctx(loggerContext(logger)).Logger()
 		*logger = l
 	})
 }
@@ -64,3 +65,31 @@ func WithApplicationName(n string) Option {
 		*logger = l
 	})
 }
+
+// WithMultiOutput writes the logs to all the given writers, e.g. JSON to a file and human-readable to the console.
+func WithMultiOutput(outputs ...io.Writer) Option {
+	return WithOutput(zerolog.MultiLevelWriter(outputs...))
+}
+
+// WithLevelWriter routes messages to writers by their level, e.g. errors to stderr.
+// Messages of levels without a dedicated writer go to the fallback writer.
+func WithLevelWriter(writers map[zerolog.Level]io.Writer, fallback io.Writer) Option {
+	return WithOutput(&levelRouter{writers: writers, fallback: fallback})
+}
+
+type levelRouter struct {
+	writers  map[zerolog.Level]io.Writer
+	fallback io.Writer
+}
+
+func (r *levelRouter) Write(p []byte) (int, error) {
+	return r.fallback.Write(p)
+}
+
+func (r *levelRouter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if w, ok := r.writers[level]; ok {
+		return w.Write(p)
+	}
+
+	return r.fallback.Write(p)
+}
@@ -0,0 +1,37 @@
+package homelogger
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMultiOutput(t *testing.T) {
+	t.Parallel()
+
+	var first, second bytes.Buffer
+
+	logger := New(WithMultiOutput(&first, &second))
+	logger.Info().Msg("hello")
+
+	assert.Contains(t, first.String(), "hello")
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestWithLevelWriter(t *testing.T) {
+	t.Parallel()
+
+	var errs, rest bytes.Buffer
+
+	logger := New(WithLevelWriter(map[zerolog.Level]io.Writer{zerolog.ErrorLevel: &errs}, &rest))
+	logger.Error().Msg("failure")
+	logger.Info().Msg("progress")
+
+	assert.Contains(t, errs.String(), "failure")
+	assert.NotContains(t, errs.String(), "progress")
+	assert.Contains(t, rest.String(), "progress")
+	assert.NotContains(t, rest.String(), "failure")
+}
@@ -0,0 +1,57 @@
+package homelogger
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Timestamp layouts expected by the managed log viewers.
+const (
+	gcpTimeFormat        = time.RFC3339Nano
+	cloudWatchTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// NewForGCP returns a production logger for Google Cloud Logging: the "severity" field classifies the messages
+// and the "time" field holds the UTC timestamp with nanoseconds.
+func NewForGCP(appName string) *zerolog.Logger {
+	return newForGCP(appName, os.Stdout)
+}
+
+func newForGCP(appName string, out io.Writer) *zerolog.Logger {
+	return New(
+		WithLevel(zerolog.InfoLevel),
+		WithOutput(out),
+		WithCaller(),
+		WithStack(),
+		WithApplicationName(appName),
+		WithHook(GCPSeverityHook(), TimestampHook("time", gcpTimeFormat)),
+	)
+}
+
+// NewForCloudWatch returns a production logger for AWS CloudWatch Logs: the "severity" field holds
+// the upper-case level name and the "timestamp" field the UTC time with milliseconds, as used by AWS services.
+func NewForCloudWatch(appName string) *zerolog.Logger {
+	return newForCloudWatch(appName, os.Stdout)
+}
+
+func newForCloudWatch(appName string, out io.Writer) *zerolog.Logger {
+	return New(
+		WithLevel(zerolog.InfoLevel),
+		WithOutput(out),
+		WithCaller(),
+		WithStack(),
+		WithApplicationName(appName),
+		WithHook(CloudWatchSeverityHook(), TimestampHook("timestamp", cloudWatchTimeFormat)),
+	)
+}
+
+// TimestampHook adds the current UTC time formatted with the layout under the key.
+// Unlike WithTime, it doesn't depend on the global zerolog.TimeFieldFormat.
+func TimestampHook(key, layout string) zerolog.Hook {
+	return zerolog.HookFunc(func(e *zerolog.Event, _ zerolog.Level, _ string) {
+		e.Str(key, time.Now().UTC().Format(layout))
+	})
+}
@@ -0,0 +1,73 @@
+package homelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudPresets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		build        func(buf *bytes.Buffer) func()
+		timeKey      string
+		timeFormat   string
+		wantSeverity string
+	}{
+		{
+			name: "GCP",
+			build: func(buf *bytes.Buffer) func() {
+				logger := newForGCP("billing", buf)
+				return func() { logger.Warn().Msg("disk almost full") }
+			},
+			timeKey:      "time",
+			timeFormat:   time.RFC3339Nano,
+			wantSeverity: "WARNING",
+		},
+		{
+			name: "CloudWatch",
+			build: func(buf *bytes.Buffer) func() {
+				logger := newForCloudWatch("billing", buf)
+				return func() { logger.Warn().Msg("disk almost full") }
+			},
+			timeKey:      "timestamp",
+			timeFormat:   cloudWatchTimeFormat,
+			wantSeverity: "WARN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			tt.build(&buf)()
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+			assert.Equal(t, tt.wantSeverity, entry["severity"])
+			assert.Equal(t, "billing", entry[applicationKey])
+			assert.Equal(t, "disk almost full", entry["message"])
+			assert.Contains(t, entry, "caller")
+
+			ts, ok := entry[tt.timeKey].(string)
+			require.True(t, ok)
+
+			parsed, err := time.Parse(tt.timeFormat, ts)
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now(), parsed, time.Minute)
+			assert.Equal(t, time.UTC, parsed.Location())
+		})
+	}
+
+	assert.NotNil(t, NewForGCP("app"))
+	assert.NotNil(t, NewForCloudWatch("app"))
+}
@@ -0,0 +1,195 @@
+package homelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// RedactedValue replaces the values of redacted fields by default.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are redacted by WithRedaction if no fields are given.
+var DefaultRedactedFields = []string{"password", "token", "authorization", "secret", "api_key", "cookie"}
+
+// MaskFunc returns the replacement of a redacted field value. The value is the decoded string
+// for JSON strings and the raw JSON otherwise.
+type MaskFunc func(key, value string) string
+
+// WithRedaction writes the logs to out replacing the values of the given fields, matched case-insensitively
+// at any nesting level, with the result of mask (RedactedValue if nil). Only JSON output is redacted.
+func WithRedaction(out io.Writer, mask MaskFunc, fields ...string) Option {
+	return WithOutput(NewRedactingWriter(out, mask, fields...))
+}
+
+// RedactingWriter is a zerolog.LevelWriter masking sensitive fields of JSON messages, see WithRedaction.
+type RedactingWriter struct {
+	out    zerolog.LevelWriter
+	mask   MaskFunc
+	fields map[string]bool
+	// needles are the quoted lowercase field names, to skip messages without them quickly
+	needles [][]byte
+}
+
+// NewRedactingWriter returns a new RedactingWriter, DefaultRedactedFields are used if no fields are given.
+func NewRedactingWriter(out io.Writer, mask MaskFunc, fields ...string) *RedactingWriter {
+	lw, ok := out.(zerolog.LevelWriter)
+	if !ok {
+		lw = zerolog.LevelWriterAdapter{Writer: out}
+	}
+
+	if mask == nil {
+		mask = func(string, string) string { return RedactedValue }
+	}
+
+	if len(fields) == 0 {
+		fields = DefaultRedactedFields
+	}
+
+	w := &RedactingWriter{out: lw, mask: mask, fields: make(map[string]bool, len(fields))}
+
+	for _, f := range fields {
+		f = strings.ToLower(f)
+		w.fields[f] = true
+		w.needles = append(w.needles, []byte(`"`+f+`"`))
+	}
+
+	return w
+}
+
+// Write implements io.Writer.
+func (w *RedactingWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (w *RedactingWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if !w.mayContainFields(p) {
+		return w.out.WriteLevel(level, p)
+	}
+
+	redacted, ok := w.redactObject(bytes.TrimSpace(p))
+	if !ok {
+		return w.out.WriteLevel(level, p)
+	}
+
+	if _, err := w.out.WriteLevel(level, append(redacted, '\n')); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (w *RedactingWriter) mayContainFields(p []byte) bool {
+	lower := bytes.ToLower(p)
+
+	for _, needle := range w.needles {
+		if bytes.Contains(lower, needle) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactObject re-encodes the JSON object keeping the order of its fields.
+func (w *RedactingWriter) redactObject(data []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+
+	out := []byte{'{'}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+
+		key, _ := tok.(string)
+
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, false
+		}
+
+		switch {
+		case w.fields[strings.ToLower(key)]:
+			raw := string(value)
+
+			var s string
+			if json.Unmarshal(value, &s) == nil {
+				raw = s
+			}
+
+			value, _ = json.Marshal(w.mask(key, raw))
+		default:
+			value = w.redactValue(value)
+		}
+
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+
+		encodedKey, _ := json.Marshal(key)
+		out = append(out, encodedKey...)
+		out = append(out, ':')
+		out = append(out, value...)
+	}
+
+	return append(out, '}'), true
+}
+
+// redactArray re-encodes the JSON array redacting its elements.
+func (w *RedactingWriter) redactArray(data []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, false
+	}
+
+	out := []byte{'['}
+
+	for dec.More() {
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+
+		out = append(out, w.redactValue(value)...)
+	}
+
+	return append(out, ']'), true
+}
+
+// redactValue redacts the nested objects and arrays, other values are returned as is.
+func (w *RedactingWriter) redactValue(value json.RawMessage) json.RawMessage {
+	var (
+		redacted []byte
+		ok       bool
+	)
+
+	switch {
+	case len(value) == 0:
+		return value
+	case value[0] == '{':
+		redacted, ok = w.redactObject(value)
+	case value[0] == '[':
+		redacted, ok = w.redactArray(value)
+	}
+
+	if !ok {
+		return value
+	}
+
+	return redacted
+}
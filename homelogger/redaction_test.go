@@ -0,0 +1,79 @@
+package homelogger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRedaction(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := New(WithRedaction(&buf, nil))
+
+	logger.Info().
+		Str("user", "alice").
+		Str("Password", "hunter2").
+		Dict("request", zerolog.Dict().Str("Authorization", "Bearer abc").Int("status", 200)).
+		Msg("login")
+
+	logger.Info().Str("path", "/health").Msg("no secrets")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t,
+		`{"level":"info","user":"alice","Password":"[REDACTED]","request":{"Authorization":"[REDACTED]","status":200},"message":"login"}`,
+		lines[0])
+	assert.Equal(t, `{"level":"info","path":"/health","message":"no secrets"}`, lines[1])
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestWithRedaction_Arrays(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	w := NewRedactingWriter(&buf, nil)
+	_, err := w.Write([]byte(`{"users":[{"password":"hunter2","token":"x"},[{"secret":1}],"password"],"n":[1,2]}` + "\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"users":[{"password":"[REDACTED]","token":"[REDACTED]"},[{"secret":"[REDACTED]"}],"password"],"n":[1,2]}`+"\n",
+		buf.String())
+}
+
+func TestWithRedaction_CustomMask(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	mask := func(_, value string) string {
+		if len(value) <= 4 {
+			return "****"
+		}
+
+		return "****" + value[len(value)-4:]
+	}
+
+	logger := New(WithRedaction(&buf, mask, "card", "pin"))
+	logger.Info().Str("card", "4111111111111111").Int("pin", 1234).Str("token", "kept").Msg("")
+
+	assert.Equal(t, `{"level":"info","card":"****1111","pin":"****","token":"kept"}`+"\n", buf.String())
+}
+
+func TestRedactingWriter_NotJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	w := NewRedactingWriter(&buf, nil)
+	_, err := w.Write([]byte("password=plain text line\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "password=plain text line\n", buf.String())
+}
@@ -0,0 +1,237 @@
+package homelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxFileSize = 100 * 1024 * 1024 // 100MB
+	backupTimeFormat   = "2006-01-02T15-04-05.000"
+)
+
+// RotationOption configures the log file rotation.
+type RotationOption interface {
+	apply(f *RotatingFile)
+}
+
+type rotationOptionFn func(f *RotatingFile)
+
+func (fn rotationOptionFn) apply(f *RotatingFile) {
+	fn(f)
+}
+
+// RotateMaxSize sets the size in bytes after which the file is rotated. The default is 100MB.
+func RotateMaxSize(bytes int64) RotationOption {
+	return rotationOptionFn(func(f *RotatingFile) {
+		f.maxSize = bytes
+	})
+}
+
+// RotateMaxAge removes rotated files older than the given age. By default, files are kept regardless of age.
+func RotateMaxAge(age time.Duration) RotationOption {
+	return rotationOptionFn(func(f *RotatingFile) {
+		f.maxAge = age
+	})
+}
+
+// RotateMaxBackups sets the number of rotated files to keep. By default, all rotated files are kept.
+func RotateMaxBackups(n int) RotationOption {
+	return rotationOptionFn(func(f *RotatingFile) {
+		f.maxBackups = n
+	})
+}
+
+// WithFile writes the logs into the file at path, rotating it according to the given options.
+// Errors of opening or rotating the file are reported by zerolog.ErrorHandler on write.
+func WithFile(path string, rotation ...RotationOption) Option {
+	return WithOutput(NewRotatingFile(path, rotation...))
+}
+
+// RotatingFile is an io.WriteCloser that writes into a file and rotates it once it grows beyond the max size.
+// Rotated files are renamed to <name>-<UTC timestamp><ext> and kept in the same directory.
+type RotatingFile struct {
+	file *os.File
+	now  func() time.Time
+
+	path       string
+	size       int64
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mutex sync.Mutex
+}
+
+// NewRotatingFile returns a RotatingFile for the given path. The file is opened on the first write.
+func NewRotatingFile(path string, opts ...RotationOption) *RotatingFile {
+	f := &RotatingFile{
+		path:    path,
+		maxSize: defaultMaxFileSize,
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt.apply(f)
+	}
+
+	return f
+}
+
+// Write implements io.Writer.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Rotate forces the rotation of the current file.
+func (f *RotatingFile) Rotate() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.rotate()
+}
+
+// Close implements io.Closer.
+func (f *RotatingFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+func (f *RotatingFile) rotate() error {
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+
+		f.file = nil
+	}
+
+	if _, err := os.Stat(f.path); err == nil {
+		if err = os.Rename(f.path, f.backupName(f.now())); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.removeStaleBackups()
+
+	return nil
+}
+
+func (f *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+
+	return fmt.Sprintf("%s-%s%s", base, t.UTC().Format(backupTimeFormat), ext)
+}
+
+// backups returns the rotated files sorted from the newest to the oldest.
+// Other files matching the pattern, e.g. "app-error.log" next to "app.log", aren't backups.
+func (f *RotatingFile) backups() []string {
+	ext := filepath.Ext(f.path)
+	pattern := strings.TrimSuffix(f.path, ext) + "-*" + ext
+
+	matches, _ := filepath.Glob(pattern)
+
+	backups := matches[:0]
+
+	for _, match := range matches {
+		if _, ok := f.backupTime(match); ok {
+			backups = append(backups, match)
+		}
+	}
+
+	// the timestamp format sorts lexicographically
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	return backups
+}
+
+// backupTime returns the rotation time of the backup, the second return value is false
+// if the name isn't a backup name of the file.
+func (f *RotatingFile) backupTime(name string) (time.Time, bool) {
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(f.path, ext) + "-"
+
+	ts, ok := strings.CutPrefix(name, prefix)
+	if !ok || !strings.HasSuffix(ts, ext) {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(backupTimeFormat, strings.TrimSuffix(ts, ext))
+
+	return t, err == nil
+}
+
+func (f *RotatingFile) removeStaleBackups() {
+	for i, backup := range f.backups() {
+		stale := f.maxBackups > 0 && i >= f.maxBackups
+
+		if !stale && f.maxAge > 0 {
+			ts, _ := f.backupTime(backup)
+			stale = f.now().Sub(ts) > f.maxAge
+		}
+
+		if stale {
+			_ = os.Remove(backup)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package homelogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile_MaxSizeAndBackups(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f := NewRotatingFile(path, RotateMaxSize(10), RotateMaxBackups(2))
+	f.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	defer f.Close()
+
+	for _, line := range []string{"line-1\n", "line-2\n", "line-3\n", "line-4\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "line-4\n", string(content))
+
+	backups := f.backups()
+	require.Len(t, backups, 2)
+
+	newest, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, "line-3\n", string(newest))
+	assert.True(t, strings.HasSuffix(backups[0], ".log"))
+}
+
+func TestRotatingFile_MaxAge(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f := NewRotatingFile(path, RotateMaxAge(time.Hour))
+	f.now = func() time.Time { return now }
+
+	defer f.Close()
+
+	_, err := f.Write([]byte("old\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Rotate())
+	require.Len(t, f.backups(), 1)
+
+	now = now.Add(2 * time.Hour)
+
+	_, err = f.Write([]byte("new\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Rotate())
+
+	backups := f.backups()
+	require.Len(t, backups, 1)
+
+	content, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, "new\n", string(content))
+}
+
+func TestRotatingFile_KeepsSiblingFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	sibling := filepath.Join(dir, "app-error.log")
+	require.NoError(t, os.WriteFile(sibling, []byte("error\n"), 0o600))
+
+	f := NewRotatingFile(path, RotateMaxBackups(1), RotateMaxAge(time.Hour))
+
+	defer f.Close()
+
+	for range 3 {
+		_, err := f.Write([]byte("line\n"))
+		require.NoError(t, err)
+		require.NoError(t, f.Rotate())
+	}
+
+	backups := f.backups()
+	require.Len(t, backups, 1)
+	assert.NotEqual(t, sibling, backups[0])
+	assert.FileExists(t, sibling)
+}
+
+func TestWithFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger := New(WithFile(path))
+	logger.Info().Msg("to file")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "to file")
+}
@@ -0,0 +1,102 @@
+package homelogger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// rateLimitSweepSize is the number of tracked messages after which stale ones are removed.
+const rateLimitSweepSize = 1024
+
+// WithSampling logs only every n-th message.
+func WithSampling(n uint32) Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		l := logger.Sample(&zerolog.BasicSampler{N: n})
+		*logger = l
+	})
+}
+
+// WithBurstSampler allows burst messages of the given level per period, the rest of them are dropped.
+// Messages of other levels are not sampled.
+func WithBurstSampler(burst uint32, period time.Duration, level zerolog.Level) Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		var (
+			ls      zerolog.LevelSampler
+			sampler = &zerolog.BurstSampler{Burst: burst, Period: period}
+		)
+
+		switch level { //nolint:exhaustive
+		case zerolog.TraceLevel:
+			ls.TraceSampler = sampler
+		case zerolog.DebugLevel:
+			ls.DebugSampler = sampler
+		case zerolog.InfoLevel:
+			ls.InfoSampler = sampler
+		case zerolog.WarnLevel:
+			ls.WarnSampler = sampler
+		case zerolog.ErrorLevel:
+			ls.ErrorSampler = sampler
+		default:
+			return
+		}
+
+		l := logger.Sample(ls)
+		*logger = l
+	})
+}
+
+// WithRateLimit logs identical messages of the same level at most once per interval,
+// which keeps noisy loops (e.g. retries) from flooding the output.
+func WithRateLimit(interval time.Duration) Option {
+	return optionFn(func(logger *zerolog.Logger) {
+		l := logger.Hook(newRateLimitHook(interval))
+		*logger = l
+	})
+}
+
+type rateLimitHook struct {
+	lastSeen map[rateLimitKey]time.Time
+	now      func() time.Time
+	interval time.Duration
+
+	mutex sync.Mutex
+}
+
+type rateLimitKey struct {
+	msg   string
+	level zerolog.Level
+}
+
+func newRateLimitHook(interval time.Duration) *rateLimitHook {
+	return &rateLimitHook{
+		lastSeen: make(map[rateLimitKey]time.Time),
+		now:      time.Now,
+		interval: interval,
+	}
+}
+
+func (h *rateLimitHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	now := h.now()
+	key := rateLimitKey{msg: msg, level: level}
+
+	if last, ok := h.lastSeen[key]; ok && now.Sub(last) < h.interval {
+		e.Discard()
+
+		return
+	}
+
+	if len(h.lastSeen) >= rateLimitSweepSize {
+		for k, last := range h.lastSeen {
+			if now.Sub(last) >= h.interval {
+				delete(h.lastSeen, k)
+			}
+		}
+	}
+
+	h.lastSeen[key] = now
+}
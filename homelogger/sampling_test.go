@@ -0,0 +1,65 @@
+package homelogger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSampling(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := New(WithOutput(&buf), WithSampling(3))
+
+	for i := 0; i < 9; i++ {
+		logger.Info().Msg("sampled")
+	}
+
+	assert.Equal(t, 3, strings.Count(buf.String(), "sampled"))
+}
+
+func TestWithBurstSampler(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := New(WithOutput(&buf), WithBurstSampler(2, time.Hour, zerolog.DebugLevel))
+
+	for i := 0; i < 5; i++ {
+		logger.Debug().Msg("debug")
+		logger.Info().Msg("info")
+	}
+
+	assert.Equal(t, 2, strings.Count(buf.String(), `"debug"}`))
+	assert.Equal(t, 5, strings.Count(buf.String(), `"info"}`))
+}
+
+func TestRateLimitHook(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	now := time.Now()
+	hook := newRateLimitHook(time.Minute)
+	hook.now = func() time.Time { return now }
+
+	logger := zerolog.New(&buf).Hook(hook)
+
+	logger.Info().Msg("retrying")
+	logger.Info().Msg("retrying")
+	logger.Error().Msg("retrying")
+	logger.Info().Msg("other")
+
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+
+	now = now.Add(time.Minute)
+	logger.Info().Msg("retrying")
+
+	assert.Equal(t, 4, strings.Count(buf.String(), "\n"))
+}
@@ -0,0 +1,171 @@
+package homelogger
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+var _ slog.Handler = (*slogHandler)(nil)
+
+// NewSlogHandler returns a slog.Handler that writes through a zerolog logger configured with the given options.
+func NewSlogHandler(options ...Option) slog.Handler {
+	return SlogHandlerFrom(New(options...))
+}
+
+// SlogHandlerFrom returns a slog.Handler that writes through the given zerolog logger.
+func SlogHandlerFrom(logger *zerolog.Logger) slog.Handler {
+	return &slogHandler{logger: *logger}
+}
+
+// ToSlog wraps the zerolog logger into a slog.Logger.
+func ToSlog(logger *zerolog.Logger) *slog.Logger {
+	return slog.New(SlogHandlerFrom(logger))
+}
+
+// FromSlog returns a zerolog logger that forwards every entry to the slog logger.
+func FromSlog(logger *slog.Logger) *zerolog.Logger {
+	l := zerolog.New(&slogWriter{logger: logger}).With().Timestamp().Logger()
+
+	return &l
+}
+
+type slogHandler struct {
+	logger zerolog.Logger
+	prefix string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	lvl := zerologLevel(level)
+
+	return lvl >= h.logger.GetLevel() && lvl >= zerolog.GlobalLevel()
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	e := h.logger.WithLevel(zerologLevel(r.Level))
+	if e == nil {
+		return nil
+	}
+
+	fields := make([]any, 0, 2*r.NumAttrs())
+
+	r.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix, attr)
+
+		return true
+	})
+
+	e.Fields(fields).Msg(r.Message)
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]any, 0, 2*len(attrs))
+
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, h.prefix, attr)
+	}
+
+	return &slogHandler{
+		logger: h.logger.With().Fields(fields).Logger(),
+		prefix: h.prefix,
+	}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &slogHandler{logger: h.logger, prefix: h.prefix + name + "."}
+}
+
+// appendSlogAttr appends the attribute as a key-value pair, keys of grouped attributes are joined with dots.
+func appendSlogAttr(fields []any, prefix string, attr slog.Attr) []any {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	key := prefix + attr.Key
+
+	if attr.Value.Kind() != slog.KindGroup {
+		return append(fields, key, attr.Value.Any())
+	}
+
+	groupPrefix := prefix
+	if attr.Key != "" {
+		groupPrefix = key + "."
+	}
+
+	for _, a := range attr.Value.Group() {
+		fields = appendSlogAttr(fields, groupPrefix, a)
+	}
+
+	return fields
+}
+
+func zerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zerolog.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zerolog.WarnLevel
+	case level >= slog.LevelInfo:
+		return zerolog.InfoLevel
+	case level >= slog.LevelDebug:
+		return zerolog.DebugLevel
+	default:
+		return zerolog.TraceLevel
+	}
+}
+
+func slogLevel(level zerolog.Level) slog.Level {
+	switch level { //nolint:exhaustive
+	case zerolog.TraceLevel:
+		return slog.LevelDebug - 4
+	case zerolog.DebugLevel:
+		return slog.LevelDebug
+	case zerolog.WarnLevel:
+		return slog.LevelWarn
+	case zerolog.ErrorLevel, zerolog.FatalLevel, zerolog.PanicLevel:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// slogWriter decodes zerolog JSON entries and forwards them to a slog logger.
+type slogWriter struct {
+	logger *slog.Logger
+}
+
+func (w *slogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *slogWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(p, &fields); err != nil {
+		return 0, err
+	}
+
+	msg, _ := fields[zerolog.MessageFieldName].(string)
+
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.TimestampFieldName)
+
+	attrs := make([]slog.Attr, 0, len(fields))
+	for k, v := range fields {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+
+	w.logger.LogAttrs(context.Background(), slogLevel(level), msg, attrs...)
+
+	return len(p), nil
+}
@@ -0,0 +1,72 @@
+package homelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSlogHandler(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(NewSlogHandler(WithOutput(&buf), WithLevel(zerolog.InfoLevel), WithApplicationName("app")))
+
+	logger.Debug("skipped")
+	logger.With("request_id", "42").WithGroup("http").Info("request",
+		"status", 200,
+		"elapsed", time.Second,
+		slog.Group("user", "id", 7),
+		"err", errors.New("boom"),
+	)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "request", entry["message"])
+	assert.Equal(t, "app", entry["application"])
+	assert.Equal(t, "42", entry["request_id"])
+	assert.InDelta(t, 200, entry["http.status"], 0)
+	assert.InDelta(t, 1000, entry["http.elapsed"], 0)
+	assert.InDelta(t, 7, entry["http.user.id"], 0)
+	assert.Equal(t, "boom", entry["http.err"])
+}
+
+func TestFromSlog(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := FromSlog(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	logger.Warn().Str("key", "value").Msg("hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "hello", entry["msg"])
+	assert.Equal(t, "value", entry["key"])
+}
+
+func TestLevelMapping(t *testing.T) {
+	t.Parallel()
+
+	levels := []zerolog.Level{zerolog.TraceLevel, zerolog.DebugLevel, zerolog.InfoLevel, zerolog.WarnLevel, zerolog.ErrorLevel}
+
+	for _, level := range levels {
+		assert.Equal(t, level, zerologLevel(slogLevel(level)))
+	}
+}
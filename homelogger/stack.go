@@ -0,0 +1,30 @@
+package homelogger
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/pkgerrors"
+)
+
+type stackTracer interface {
+	error
+	StackTrace() errors.StackTrace
+}
+
+// WithErrorStackMarshaler makes errors logged with Stack() (see WithStack) emit the stack frames
+// recorded by github.com/pkg/errors, also when such errors are wrapped with fmt.Errorf("%w") or errors.Join.
+// Note that it sets the global zerolog.ErrorStackMarshaler, so it affects all loggers.
+func WithErrorStackMarshaler() Option {
+	return optionFn(func(*zerolog.Logger) {
+		zerolog.ErrorStackMarshaler = marshalErrorStack
+	})
+}
+
+func marshalErrorStack(err error) any {
+	var st stackTracer
+	if !errors.As(err, &st) {
+		return nil
+	}
+
+	return pkgerrors.MarshalStack(st)
+}
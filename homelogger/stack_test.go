@@ -0,0 +1,61 @@
+package homelogger
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalErrorStack(t *testing.T) {
+	t.Parallel()
+
+	withStack := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		err       error
+		wantStack bool
+	}{
+		{
+			name:      "pkg/errors error",
+			err:       withStack,
+			wantStack: true,
+		},
+		{
+			name:      "Wrapped with fmt.Errorf",
+			err:       fmt.Errorf("context: %w", withStack),
+			wantStack: true,
+		},
+		{
+			name:      "Joined errors",
+			err:       stderrors.Join(stderrors.New("plain"), withStack),
+			wantStack: true,
+		},
+		{
+			name:      "Standard error",
+			err:       stderrors.New("plain"),
+			wantStack: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stack := marshalErrorStack(tt.err)
+			if !tt.wantStack {
+				assert.Nil(t, stack)
+				return
+			}
+
+			frames, ok := stack.([]map[string]string)
+			require.True(t, ok)
+			require.NotEmpty(t, frames)
+			assert.Equal(t, "TestMarshalErrorStack", frames[0]["func"])
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package homelogger
+
+import (
+	"bytes"
+	"io"
+	"log"
+
+	"github.com/rs/zerolog"
+)
+
+// AsStdLogger returns a *log.Logger writing into the logger with the level,
+// e.g. for http.Server.ErrorLog.
+func AsStdLogger(logger *zerolog.Logger, level zerolog.Level) *log.Logger {
+	return log.New(NewWriter(logger, level), "", 0)
+}
+
+// NewWriter returns an io.Writer logging every written line as a message with the level.
+func NewWriter(logger *zerolog.Logger, level zerolog.Level) io.Writer {
+	return &levelWriter{logger: logger, level: level}
+}
+
+type levelWriter struct {
+	logger *zerolog.Logger
+	level  zerolog.Level
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			w.logger.WithLevel(w.level).Msg(string(line))
+		}
+	}
+
+	return len(p), nil
+}
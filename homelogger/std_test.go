@@ -0,0 +1,36 @@
+package homelogger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsStdLogger(t *testing.T) {
+	t.Parallel()
+
+	logger, records := NewTest(t)
+
+	AsStdLogger(logger, zerolog.WarnLevel).Printf("http: TLS handshake error from %s", "127.0.0.1")
+
+	entries := records.Entries(zerolog.WarnLevel)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "http: TLS handshake error from 127.0.0.1", entries[0].Message)
+}
+
+func TestNewWriter(t *testing.T) {
+	t.Parallel()
+
+	logger, records := NewTest(t)
+
+	_, err := fmt.Fprint(NewWriter(logger, zerolog.InfoLevel), "first\n\n  second  \n")
+	require.NoError(t, err)
+
+	entries := records.Entries(zerolog.InfoLevel)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first", entries[0].Message)
+	assert.Equal(t, "second", entries[1].Message)
+}
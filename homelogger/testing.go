@@ -0,0 +1,118 @@
+package homelogger
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// Record is a single log entry collected by Records.
+type Record struct {
+	Level   zerolog.Level
+	Message string
+	Fields  map[string]any
+}
+
+// Records collects the entries written by a logger created with NewTest.
+type Records struct {
+	records []Record
+
+	mutex sync.Mutex
+}
+
+// NewTest returns a logger that logs all levels into the returned Records.
+// The entries are also written to the test log, so they are shown when the test fails.
+func NewTest(t *testing.T) (*zerolog.Logger, *Records) {
+	t.Helper()
+
+	records := &Records{}
+	logger := New(
+		WithLevel(zerolog.TraceLevel),
+		WithOutput(zerolog.MultiLevelWriter(records, zerolog.NewTestWriter(t))),
+	)
+
+	return logger, records
+}
+
+// Write implements io.Writer.
+func (r *Records) Write(p []byte) (int, error) {
+	return r.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (r *Records) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	fields := map[string]any{}
+	if err := json.Unmarshal(p, &fields); err != nil {
+		return 0, err
+	}
+
+	msg, _ := fields[zerolog.MessageFieldName].(string)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.LevelFieldName)
+
+	r.mutex.Lock()
+	r.records = append(r.records, Record{Level: level, Message: msg, Fields: fields})
+	r.mutex.Unlock()
+
+	return len(p), nil
+}
+
+// All returns all collected entries.
+func (r *Records) All() []Record {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Record(nil), r.records...)
+}
+
+// Entries returns the entries with the given level.
+func (r *Records) Entries(level zerolog.Level) []Record {
+	var result []Record
+
+	for _, rec := range r.All() {
+		if rec.Level == level {
+			result = append(result, rec)
+		}
+	}
+
+	return result
+}
+
+// HasMessage reports whether an entry with the given message was logged.
+func (r *Records) HasMessage(msg string) bool {
+	_, ok := r.find(msg)
+
+	return ok
+}
+
+// FieldValue returns the value of the field of the first entry with the given message.
+// Numbers are decoded as float64, as with encoding/json.
+func (r *Records) FieldValue(msg, field string) (any, bool) {
+	rec, ok := r.find(msg)
+	if !ok {
+		return nil, false
+	}
+
+	v, ok := rec.Fields[field]
+
+	return v, ok
+}
+
+// Reset removes all collected entries.
+func (r *Records) Reset() {
+	r.mutex.Lock()
+	r.records = nil
+	r.mutex.Unlock()
+}
+
+func (r *Records) find(msg string) (Record, bool) {
+	for _, rec := range r.All() {
+		if rec.Message == msg {
+			return rec, true
+		}
+	}
+
+	return Record{}, false
+}
@@ -0,0 +1,39 @@
+package homelogger
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTest(t *testing.T) {
+	t.Parallel()
+
+	logger, records := NewTest(t)
+
+	logger.Debug().Int("attempt", 2).Msg("retrying")
+	logger.Error().Str("user", "bob").Msg("failed")
+	logger.Error().Msg("failed again")
+
+	require.Len(t, records.All(), 3)
+	assert.Len(t, records.Entries(zerolog.ErrorLevel), 2)
+	assert.Len(t, records.Entries(zerolog.InfoLevel), 0)
+	assert.True(t, records.HasMessage("retrying"))
+	assert.False(t, records.HasMessage("unknown"))
+
+	v, ok := records.FieldValue("failed", "user")
+	assert.True(t, ok)
+	assert.Equal(t, "bob", v)
+
+	v, ok = records.FieldValue("retrying", "attempt")
+	assert.True(t, ok)
+	assert.InDelta(t, 2, v, 0)
+
+	_, ok = records.FieldValue("failed again", "user")
+	assert.False(t, ok)
+
+	records.Reset()
+	assert.Empty(t, records.All())
+}
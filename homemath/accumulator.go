@@ -0,0 +1,144 @@
+package homemath
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultReservoirSize is the number of samples an Accumulator keeps for quantile estimation.
+const DefaultReservoirSize = 1024
+
+// Accumulator aggregates a stream of values in constant memory: count, mean, min, max and variance are exact
+// (Welford's algorithm), quantiles are estimated from a uniform reservoir sample. It is safe for concurrent use.
+type Accumulator struct {
+	reservoir []float64
+	rnd       *rand.Rand
+	count     int64
+	mean      float64
+	m2        float64
+	min       float64
+	max       float64
+
+	mutex sync.Mutex
+}
+
+// NewAccumulator returns an Accumulator keeping up to reservoirSize samples for quantiles,
+// DefaultReservoirSize if it is not positive.
+func NewAccumulator(reservoirSize int) *Accumulator {
+	if reservoirSize <= 0 {
+		reservoirSize = DefaultReservoirSize
+	}
+
+	return &Accumulator{
+		reservoir: make([]float64, 0, reservoirSize),
+		rnd:       rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+}
+
+// Add adds the value.
+func (a *Accumulator) Add(x float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.count++
+
+	if a.count == 1 || x < a.min {
+		a.min = x
+	}
+
+	if a.count == 1 || x > a.max {
+		a.max = x
+	}
+
+	delta := x - a.mean
+	a.mean += delta / float64(a.count)
+	a.m2 += delta * (x - a.mean)
+
+	if len(a.reservoir) < cap(a.reservoir) {
+		a.reservoir = append(a.reservoir, x)
+	} else if i := a.rnd.Int63n(a.count); i < int64(len(a.reservoir)) {
+		a.reservoir[i] = x
+	}
+}
+
+// AddDuration adds the duration in seconds, e.g. a request latency.
+func (a *Accumulator) AddDuration(d time.Duration) {
+	a.Add(d.Seconds())
+}
+
+// Count returns the number of added values.
+func (a *Accumulator) Count() int64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.count
+}
+
+// Mean returns the mean of the added values.
+func (a *Accumulator) Mean() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.mean
+}
+
+// Min returns the smallest added value.
+func (a *Accumulator) Min() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.min
+}
+
+// Max returns the largest added value.
+func (a *Accumulator) Max() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.max
+}
+
+// Variance returns the population variance of the added values.
+func (a *Accumulator) Variance() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.count == 0 {
+		return 0
+	}
+
+	return a.m2 / float64(a.count)
+}
+
+// StdDev returns the population standard deviation of the added values.
+func (a *Accumulator) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// Quantile returns the estimated q-quantile (0-1), e.g. 0.99. It is exact while fewer values
+// than the reservoir size were added.
+func (a *Accumulator) Quantile(q float64) float64 {
+	a.mutex.Lock()
+	sorted := slices.Clone(a.reservoir)
+	a.mutex.Unlock()
+
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	slices.Sort(sorted)
+
+	return percentileSorted(sorted, q*100)
+}
+
+// Reset removes all added values.
+func (a *Accumulator) Reset() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.reservoir = a.reservoir[:0]
+	a.count, a.mean, a.m2, a.min, a.max = 0, 0, 0, 0, 0
+}
@@ -0,0 +1,67 @@
+package homemath
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccumulator(t *testing.T) {
+	t.Parallel()
+
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	acc := NewAccumulator(0)
+
+	assert.InDelta(t, 0, acc.Quantile(0.5), 0)
+	assert.InDelta(t, 0, acc.Variance(), 0)
+
+	for _, v := range values {
+		acc.Add(v)
+	}
+
+	assert.Equal(t, int64(len(values)), acc.Count())
+	assert.InDelta(t, Mean(values...), acc.Mean(), 1e-9)
+	assert.InDelta(t, 2, acc.Min(), 0)
+	assert.InDelta(t, 9, acc.Max(), 0)
+	assert.InDelta(t, Variance(values...), acc.Variance(), 1e-9)
+	assert.InDelta(t, 2, acc.StdDev(), 1e-9)
+	assert.InDelta(t, Median(values...), acc.Quantile(0.5), 1e-9)
+
+	acc.Reset()
+	assert.Equal(t, int64(0), acc.Count())
+
+	acc.AddDuration(-time.Second)
+	assert.InDelta(t, -1, acc.Min(), 0)
+	assert.InDelta(t, -1, acc.Max(), 0)
+}
+
+func TestAccumulator_Reservoir(t *testing.T) {
+	t.Parallel()
+
+	acc := NewAccumulator(256)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := w; i < 10000; i += 4 {
+				acc.Add(float64(i))
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int64(10000), acc.Count())
+	assert.InDelta(t, 4999.5, acc.Mean(), 1e-6)
+	assert.InDelta(t, 0, acc.Min(), 0)
+	assert.InDelta(t, 9999, acc.Max(), 0)
+	assert.InDelta(t, 5000, acc.Quantile(0.5), 1500)
+	assert.InDelta(t, 9000, acc.Quantile(0.9), 1000)
+}
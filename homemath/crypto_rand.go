@@ -0,0 +1,76 @@
+package homemath
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Alphabets for RandString.
+const (
+	AlphabetDigits       = "0123456789"
+	AlphabetLowercase    = "abcdefghijklmnopqrstuvwxyz"
+	AlphabetAlphanumeric = AlphabetDigits + AlphabetLowercase + "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+var (
+	ErrInvalidRange    = errors.New("invalid range")
+	ErrInvalidAlphabet = errors.New("invalid alphabet")
+)
+
+// CryptoRandInt returns a uniform random number in [0, n) from crypto/rand, e.g. for tokens.
+func CryptoRandInt(n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: n must be positive, got %d", ErrInvalidRange, n)
+	}
+
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("read random number: %w", err)
+	}
+
+	return int(v.Int64()), nil
+}
+
+// CryptoRandIntRange returns a uniform random number in [lo, hi] from crypto/rand.
+func CryptoRandIntRange(lo, hi int) (int, error) {
+	if lo > hi {
+		return 0, fmt.Errorf("%w: [%d, %d]", ErrInvalidRange, lo, hi)
+	}
+
+	span := new(big.Int).Sub(big.NewInt(int64(hi)), big.NewInt(int64(lo)))
+	span.Add(span, big.NewInt(1))
+
+	v, err := rand.Int(rand.Reader, span)
+	if err != nil {
+		return 0, fmt.Errorf("read random number: %w", err)
+	}
+
+	return int(v.Add(v, big.NewInt(int64(lo))).Int64()), nil
+}
+
+// RandString returns a string of n characters chosen uniformly from the alphabet with crypto/rand.
+func RandString(n int, alphabet string) (string, error) {
+	chars := []rune(alphabet)
+	if len(chars) == 0 {
+		return "", fmt.Errorf("%w: alphabet is empty", ErrInvalidAlphabet)
+	}
+
+	if n < 0 {
+		return "", fmt.Errorf("%w: length must not be negative, got %d", ErrInvalidRange, n)
+	}
+
+	out := make([]rune, n)
+
+	for i := range out {
+		idx, err := CryptoRandInt(len(chars))
+		if err != nil {
+			return "", err
+		}
+
+		out[i] = chars[idx]
+	}
+
+	return string(out), nil
+}
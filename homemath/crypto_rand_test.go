@@ -0,0 +1,73 @@
+package homemath
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoRandInt(t *testing.T) {
+	t.Parallel()
+
+	seen := map[int]bool{}
+
+	for i := 0; i < 200; i++ {
+		v, err := CryptoRandInt(5)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, v, 0)
+		require.Less(t, v, 5)
+
+		seen[v] = true
+	}
+
+	assert.Len(t, seen, 5)
+
+	_, err := CryptoRandInt(0)
+	require.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func TestCryptoRandIntRange(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 200; i++ {
+		v, err := CryptoRandIntRange(-3, 3)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, v, -3)
+		require.LessOrEqual(t, v, 3)
+	}
+
+	v, err := CryptoRandIntRange(7, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+
+	_, err = CryptoRandIntRange(math.MinInt, math.MaxInt)
+	require.NoError(t, err)
+
+	_, err = CryptoRandIntRange(2, 1)
+	require.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func TestRandString(t *testing.T) {
+	t.Parallel()
+
+	s, err := RandString(32, AlphabetAlphanumeric)
+	require.NoError(t, err)
+	assert.Len(t, s, 32)
+
+	for _, c := range s {
+		assert.True(t, strings.ContainsRune(AlphabetAlphanumeric, c))
+	}
+
+	s, err = RandString(4, "ж")
+	require.NoError(t, err)
+	assert.Equal(t, "жжжж", s)
+
+	_, err = RandString(4, "")
+	require.ErrorIs(t, err, ErrInvalidAlphabet)
+
+	_, err = RandString(-1, AlphabetDigits)
+	require.ErrorIs(t, err, ErrInvalidRange)
+}
@@ -0,0 +1,52 @@
+package homemath
+
+import (
+	"math"
+	"time"
+)
+
+// DurationJitter returns d randomly changed by up to ±fraction of it, e.g. 0.1 for ±10%.
+// The fraction is clamped to [0, 1], so the result is never negative.
+func DurationJitter(d time.Duration, fraction float64) time.Duration {
+	return DurationJitterWith(globalRand{}, d, fraction)
+}
+
+// DurationJitterWith is like DurationJitter, but uses the given source of randomness.
+func DurationJitterWith(r Randomizer, d time.Duration, fraction float64) time.Duration {
+	fraction = Clamp(fraction, 0, 1)
+	if d <= 0 || fraction == 0 {
+		return d
+	}
+
+	delta := (r.Float64()*2 - 1) * fraction * float64(d)
+
+	return d + time.Duration(delta)
+}
+
+// BackoffDuration returns the exponential backoff base*2^attempt for the zero-based attempt, capped at maxDelay.
+// A non-positive maxDelay means no cap; the result never overflows.
+func BackoffDuration(base time.Duration, attempt int, maxDelay time.Duration) time.Duration {
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64
+	}
+
+	if base <= 0 {
+		return 0
+	}
+
+	d := base
+	for i := 0; i < attempt && d < maxDelay; i++ {
+		if d > maxDelay/2 {
+			return maxDelay
+		}
+
+		d *= 2
+	}
+
+	return min(d, maxDelay)
+}
+
+// Percent returns pct percent of d, e.g. Percent(time.Second, 10) is 100ms.
+func Percent(d time.Duration, pct float64) time.Duration {
+	return time.Duration(float64(d) * pct / 100)
+}
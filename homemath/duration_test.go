@@ -0,0 +1,60 @@
+package homemath
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationJitter(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 100; i++ {
+		d := DurationJitter(time.Second, 0.1)
+		assert.GreaterOrEqual(t, d, 900*time.Millisecond)
+		assert.LessOrEqual(t, d, 1100*time.Millisecond)
+
+		assert.GreaterOrEqual(t, DurationJitter(time.Second, 5), time.Duration(0))
+	}
+
+	assert.Equal(t, time.Second, DurationJitter(time.Second, 0))
+	assert.Equal(t, time.Duration(0), DurationJitter(0, 0.5))
+}
+
+func TestBackoffDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		base     time.Duration
+		attempt  int
+		maxDelay time.Duration
+		want     time.Duration
+	}{
+		{name: "First attempt", base: 100 * time.Millisecond, attempt: 0, maxDelay: time.Second, want: 100 * time.Millisecond},
+		{name: "Third attempt", base: 100 * time.Millisecond, attempt: 2, maxDelay: time.Second, want: 400 * time.Millisecond},
+		{name: "Capped", base: 100 * time.Millisecond, attempt: 4, maxDelay: time.Second, want: time.Second},
+		{name: "Huge attempt", base: time.Second, attempt: 1000, maxDelay: time.Minute, want: time.Minute},
+		{name: "No cap does not overflow", base: time.Second, attempt: 1000, maxDelay: 0, want: math.MaxInt64},
+		{name: "Base above cap", base: time.Minute, attempt: 0, maxDelay: time.Second, want: time.Second},
+		{name: "Zero base", base: 0, attempt: 3, maxDelay: time.Second, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, BackoffDuration(tt.base, tt.attempt, tt.maxDelay))
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 100*time.Millisecond, Percent(time.Second, 10))
+	assert.Equal(t, 1500*time.Millisecond, Percent(time.Second, 150))
+	assert.Equal(t, time.Duration(0), Percent(time.Second, 0))
+}
@@ -35,3 +35,48 @@ func Min[T constraints.Ordered](s ...T) T {
 
 	return m
 }
+
+// MinMax returns both the smallest and the largest value in a single pass.
+func MinMax[T constraints.Ordered](s ...T) (T, T) {
+	if len(s) == 0 {
+		var zero T
+		return zero, zero
+	}
+
+	lo, hi := s[0], s[0]
+	for _, v := range s[1:] {
+		if v < lo {
+			lo = v
+		}
+
+		if v > hi {
+			hi = v
+		}
+	}
+
+	return lo, hi
+}
+
+// ArgMin returns the index of the first smallest value, -1 for an empty slice.
+func ArgMin[T constraints.Ordered](s ...T) int {
+	idx := -1
+	for i, v := range s {
+		if idx < 0 || v < s[idx] {
+			idx = i
+		}
+	}
+
+	return idx
+}
+
+// ArgMax returns the index of the first largest value, -1 for an empty slice.
+func ArgMax[T constraints.Ordered](s ...T) int {
+	idx := -1
+	for i, v := range s {
+		if idx < 0 || v > s[idx] {
+			idx = i
+		}
+	}
+
+	return idx
+}
@@ -53,6 +53,10 @@ func TestMin_Max_Ints(t *testing.T) {
 			if got := Min(tt.args...); got != tt.wantMin {
 				t.Errorf("Min() = %v, want %v", got, tt.wantMin)
 			}
+
+			if gotMin, gotMax := MinMax(tt.args...); gotMin != tt.wantMin || gotMax != tt.wantMax {
+				t.Errorf("MinMax() = (%v, %v), want (%v, %v)", gotMin, gotMax, tt.wantMin, tt.wantMax)
+			}
 		})
 	}
 }
@@ -105,6 +109,10 @@ func TestMin_Max_Floats(t *testing.T) {
 			if got := Min(tt.args...); got != tt.wantMin {
 				t.Errorf("Min() = %v, want %v", got, tt.wantMin)
 			}
+
+			if gotMin, gotMax := MinMax(tt.args...); gotMin != tt.wantMin || gotMax != tt.wantMax {
+				t.Errorf("MinMax() = (%v, %v), want (%v, %v)", gotMin, gotMax, tt.wantMin, tt.wantMax)
+			}
 		})
 	}
 }
@@ -157,6 +165,54 @@ func Test_Min_Max_Strings(t *testing.T) {
 			if got := Min(tt.args...); got != tt.wantMin {
 				t.Errorf("Min() = %v, want %v", got, tt.wantMin)
 			}
+
+			if gotMin, gotMax := MinMax(tt.args...); gotMin != tt.wantMin || gotMax != tt.wantMax {
+				t.Errorf("MinMax() = (%v, %v), want (%v, %v)", gotMin, gotMax, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestArgMin_ArgMax(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		args       []int
+		wantArgMin int
+		wantArgMax int
+	}{
+		{
+			name:       "Empty slice",
+			args:       []int{},
+			wantArgMin: -1,
+			wantArgMax: -1,
+		},
+		{
+			name:       "Single element",
+			args:       []int{7},
+			wantArgMin: 0,
+			wantArgMax: 0,
+		},
+		{
+			name:       "Ties return the first index",
+			args:       []int{3, 1, 5, 1, 5},
+			wantArgMin: 1,
+			wantArgMax: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ArgMin(tt.args...); got != tt.wantArgMin {
+				t.Errorf("ArgMin() = %v, want %v", got, tt.wantArgMin)
+			}
+
+			if got := ArgMax(tt.args...); got != tt.wantArgMax {
+				t.Errorf("ArgMax() = %v, want %v", got, tt.wantArgMax)
+			}
 		})
 	}
 }
@@ -0,0 +1,57 @@
+package homemath
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Clamp limits v to the range [lo, hi].
+func Clamp[T constraints.Ordered](v, lo, hi T) T {
+	if v < lo {
+		return lo
+	}
+
+	if v > hi {
+		return hi
+	}
+
+	return v
+}
+
+// Abs returns the absolute value of v. Note that for the minimum value of a signed integer type
+// the result overflows and stays negative.
+func Abs[T constraints.Signed | constraints.Float](v T) T {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+// RoundTo rounds v to the given number of decimal places, half away from zero.
+// Negative decimals round to tens, hundreds and so on.
+func RoundTo(v float64, decimals int) float64 {
+	return scaled(v, decimals, math.Round)
+}
+
+// FloorTo rounds v down to the given number of decimal places.
+func FloorTo(v float64, decimals int) float64 {
+	return scaled(v, decimals, math.Floor)
+}
+
+// CeilTo rounds v up to the given number of decimal places.
+func CeilTo(v float64, decimals int) float64 {
+	return scaled(v, decimals, math.Ceil)
+}
+
+// Lerp linearly interpolates between a and b, t=0 returns a and t=1 returns b.
+func Lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+func scaled(v float64, decimals int, fn func(float64) float64) float64 {
+	pow := math.Pow10(decimals)
+
+	return fn(v*pow) / pow
+}
@@ -0,0 +1,65 @@
+package homemath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClamp(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 5, Clamp(10, 0, 5))
+	assert.Equal(t, 0, Clamp(-1, 0, 5))
+	assert.Equal(t, 3, Clamp(3, 0, 5))
+	assert.InDelta(t, 0.5, Clamp(0.5, 0.0, 1.0), 0)
+	assert.Equal(t, "b", Clamp("z", "a", "b"))
+}
+
+func TestAbs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 3, Abs(-3))
+	assert.Equal(t, int8(3), Abs(int8(3)))
+	assert.InDelta(t, 1.5, Abs(-1.5), 0)
+	assert.Equal(t, int8(math.MinInt8), Abs(int8(math.MinInt8)))
+}
+
+func TestRounding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fn       func(float64, int) float64
+		v        float64
+		decimals int
+		want     float64
+	}{
+		{name: "RoundTo up", fn: RoundTo, v: 1.2345, decimals: 2, want: 1.23},
+		{name: "RoundTo half", fn: RoundTo, v: 1.235, decimals: 2, want: 1.24},
+		{name: "RoundTo negative", fn: RoundTo, v: -1.255, decimals: 1, want: -1.3},
+		{name: "RoundTo tens", fn: RoundTo, v: 1234, decimals: -1, want: 1230},
+		{name: "FloorTo", fn: FloorTo, v: 1.239, decimals: 2, want: 1.23},
+		{name: "FloorTo negative", fn: FloorTo, v: -1.231, decimals: 2, want: -1.24},
+		{name: "CeilTo", fn: CeilTo, v: 1.231, decimals: 2, want: 1.24},
+		{name: "CeilTo zero decimals", fn: CeilTo, v: 1.1, decimals: 0, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tt.want, tt.fn(tt.v, tt.decimals), 1e-9)
+		})
+	}
+}
+
+func TestLerp(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 10, Lerp(10, 20, 0), 0)
+	assert.InDelta(t, 20, Lerp(10, 20, 1), 0)
+	assert.InDelta(t, 15, Lerp(10, 20, 0.5), 0)
+	assert.InDelta(t, 25, Lerp(10, 20, 1.5), 0)
+}
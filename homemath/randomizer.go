@@ -0,0 +1,27 @@
+package homemath
+
+import (
+	"math/rand"
+)
+
+// Randomizer is a source of pseudo-random numbers, *rand.Rand implements it.
+// Pass a seeded one to the *With functions to make the randomness reproducible, e.g. in tests.
+type Randomizer interface {
+	Intn(n int) int
+	Float64() float64
+	Perm(n int) []int
+	Shuffle(n int, swap func(i, j int))
+}
+
+// globalRand is the Randomizer backed by the top-level functions of math/rand.
+type globalRand struct{}
+
+func (globalRand) Intn(n int) int                     { return rand.Intn(n) }   //nolint:gosec
+func (globalRand) Float64() float64                   { return rand.Float64() } //nolint:gosec
+func (globalRand) Perm(n int) []int                   { return rand.Perm(n) }   //nolint:gosec
+func (globalRand) Shuffle(n int, swap func(i, j int)) { rand.Shuffle(n, swap) } //nolint:gosec
+
+// DefaultRandomizer returns the Randomizer backed by the global math/rand source, it is safe for concurrent use.
+func DefaultRandomizer() Randomizer {
+	return globalRand{}
+}
@@ -0,0 +1,31 @@
+package homemath
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// SafeAdd returns a+b and whether the addition overflowed the type.
+func SafeAdd[T constraints.Integer](a, b T) (T, bool) {
+	c := a + b
+
+	return c, (b > 0 && c < a) || (b < 0 && c > a)
+}
+
+// SafeMul returns a*b and whether the multiplication overflowed the type.
+func SafeMul[T constraints.Integer](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+
+	c := a * b
+
+	return c, c/b != a || ((a < 0) == (b < 0)) != (c > 0)
+}
+
+// SafeConvert converts v to the integer type To and reports whether the value did not fit,
+// e.g. SafeConvert[uint16](70000) overflows.
+func SafeConvert[To, From constraints.Integer](v From) (To, bool) {
+	r := To(v)
+
+	return r, From(r) != v || (v < 0) != (r < 0)
+}
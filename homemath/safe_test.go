@@ -0,0 +1,95 @@
+package homemath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeAdd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		a, b           int8
+		want           int8
+		wantOverflowed bool
+	}{
+		{name: "No overflow", a: 100, b: 27, want: 127},
+		{name: "Negative", a: -100, b: -28, want: -128},
+		{name: "Overflow", a: 100, b: 28, want: -128, wantOverflowed: true},
+		{name: "Underflow", a: -100, b: -29, want: 127, wantOverflowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, overflowed := SafeAdd(tt.a, tt.b)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOverflowed, overflowed)
+		})
+	}
+
+	_, overflowed := SafeAdd(uint8(200), uint8(56))
+	assert.True(t, overflowed)
+
+	_, overflowed = SafeAdd(uint8(200), uint8(55))
+	assert.False(t, overflowed)
+}
+
+func TestSafeMul(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		a, b           int8
+		wantOverflowed bool
+	}{
+		{name: "Zero", a: 0, b: math.MinInt8},
+		{name: "No overflow", a: -16, b: 8},
+		{name: "Overflow", a: 16, b: 8, wantOverflowed: true},
+		{name: "MinInt times -1", a: math.MinInt8, b: -1, wantOverflowed: true},
+		{name: "-1 times MinInt", a: -1, b: math.MinInt8, wantOverflowed: true},
+		{name: "Negative overflow", a: -64, b: 3, wantOverflowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, overflowed := SafeMul(tt.a, tt.b)
+			assert.Equal(t, tt.wantOverflowed, overflowed)
+
+			if !overflowed {
+				assert.Equal(t, int(tt.a)*int(tt.b), int(got))
+			}
+		})
+	}
+
+	_, overflowed := SafeMul(uint32(math.MaxUint16+1), uint32(math.MaxUint16+1))
+	assert.True(t, overflowed)
+}
+
+func TestSafeConvert(t *testing.T) {
+	t.Parallel()
+
+	v16, overflowed := SafeConvert[uint16](70000)
+	assert.True(t, overflowed)
+	assert.Equal(t, uint16(70000-65536), v16)
+
+	v16, overflowed = SafeConvert[uint16](65535)
+	assert.False(t, overflowed)
+	assert.Equal(t, uint16(65535), v16)
+
+	_, overflowed = SafeConvert[uint32](-1)
+	assert.True(t, overflowed)
+
+	_, overflowed = SafeConvert[int64](uint64(math.MaxUint64))
+	assert.True(t, overflowed)
+
+	v8, overflowed := SafeConvert[int8](int64(-128))
+	assert.False(t, overflowed)
+	assert.Equal(t, int8(-128), v8)
+}
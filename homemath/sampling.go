@@ -0,0 +1,72 @@
+package homemath
+
+// Shuffle randomly reorders the slice in place.
+func Shuffle[T any](s []T) {
+	ShuffleWith(globalRand{}, s)
+}
+
+// ShuffleWith is like Shuffle, but uses the given source of randomness.
+func ShuffleWith[T any](r Randomizer, s []T) {
+	r.Shuffle(len(s), func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
+
+// SampleN returns n distinct elements of the slice chosen uniformly at random, without replacement.
+// All elements in random order are returned if n exceeds the length; the slice is not modified.
+func SampleN[T any](s []T, n int) []T {
+	return SampleNWith(globalRand{}, s, n)
+}
+
+// SampleNWith is like SampleN, but uses the given source of randomness.
+func SampleNWith[T any](r Randomizer, s []T, n int) []T {
+	n = Clamp(n, 0, len(s))
+	out := make([]T, n)
+
+	indices := r.Perm(len(s))
+	for i := range out {
+		out[i] = s[indices[i]]
+	}
+
+	return out
+}
+
+// WeightedIndex returns a random index with a probability proportional to its weight.
+// Negative weights are treated as zero; -1 is returned if no weight is positive.
+func WeightedIndex(weights []float64) int {
+	return WeightedIndexWith(globalRand{}, weights)
+}
+
+// WeightedIndexWith is like WeightedIndex, but uses the given source of randomness.
+func WeightedIndexWith(r Randomizer, weights []float64) int {
+	var total float64
+
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+
+	if total <= 0 {
+		return -1
+	}
+
+	pick := r.Float64() * total
+	last := -1
+
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+
+		if pick < w {
+			return i
+		}
+
+		pick -= w
+		last = i
+	}
+
+	// floating point rounding can leave a tiny remainder
+	return last
+}
@@ -0,0 +1,97 @@
+package homemath
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffle(t *testing.T) {
+	t.Parallel()
+
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	Shuffle(s)
+
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, sorted)
+
+	Shuffle([]int{})
+}
+
+func TestSampleN(t *testing.T) {
+	t.Parallel()
+
+	s := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		name    string
+		n       int
+		wantLen int
+	}{
+		{name: "Subset", n: 3, wantLen: 3},
+		{name: "All", n: 10, wantLen: 5},
+		{name: "None", n: 0, wantLen: 0},
+		{name: "Negative", n: -1, wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SampleN(s, tt.n)
+			assert.Len(t, got, tt.wantLen)
+
+			seen := map[string]bool{}
+			for _, v := range got {
+				assert.Contains(t, s, v)
+				assert.False(t, seen[v], "duplicate %s", v)
+				seen[v] = true
+			}
+		})
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, s)
+}
+
+func TestWeightedIndex(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, -1, WeightedIndex(nil))
+	assert.Equal(t, -1, WeightedIndex([]float64{0, -1}))
+	assert.Equal(t, 1, WeightedIndex([]float64{0, 5, -2}))
+
+	counts := make([]int, 3)
+	for i := 0; i < 10000; i++ {
+		counts[WeightedIndex([]float64{1, 0, 3})]++
+	}
+
+	assert.Zero(t, counts[1])
+	assert.InDelta(t, 7500, counts[2], 500)
+}
+
+func TestSeededRandomizer(t *testing.T) {
+	t.Parallel()
+
+	run := func() ([]int, []int, int, time.Duration) {
+		r := rand.New(rand.NewSource(7)) //nolint:gosec
+
+		s := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		ShuffleWith(r, s)
+
+		return s, SampleNWith(r, s, 3), WeightedIndexWith(r, []float64{1, 2, 3}), DurationJitterWith(r, time.Second, 0.5)
+	}
+
+	s1, sample1, idx1, d1 := run()
+	s2, sample2, idx2, d2 := run()
+
+	assert.Equal(t, s1, s2)
+	assert.Equal(t, sample1, sample2)
+	assert.Equal(t, idx1, idx2)
+	assert.Equal(t, d1, d2)
+
+	assert.GreaterOrEqual(t, DefaultRandomizer().Intn(10), 0)
+}
@@ -0,0 +1,94 @@
+package homemath
+
+import (
+	"math"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Number is a constraint for the integer and float types.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Mean returns the arithmetic mean, 0 for an empty slice.
+func Mean[T Number](s ...T) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range s {
+		sum += float64(v)
+	}
+
+	return sum / float64(len(s))
+}
+
+// Median returns the middle value, the mean of the two middle values for an even length.
+func Median[T Number](s ...T) float64 {
+	return Percentile(s, 50)
+}
+
+// Mode returns the most frequent value, the first one encountered in case of a tie.
+func Mode[T Number](s ...T) T {
+	var (
+		mode  T
+		best  int
+		count = make(map[T]int, len(s))
+	)
+
+	for _, v := range s {
+		count[v]++
+		if count[v] > best {
+			mode, best = v, count[v]
+		}
+	}
+
+	return mode
+}
+
+// Variance returns the population variance, 0 for an empty slice.
+func Variance[T Number](s ...T) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	mean := Mean(s...)
+
+	var sum float64
+	for _, v := range s {
+		d := float64(v) - mean
+		sum += d * d
+	}
+
+	return sum / float64(len(s))
+}
+
+// StdDev returns the population standard deviation.
+func StdDev[T Number](s ...T) float64 {
+	return math.Sqrt(Variance(s...))
+}
+
+// Percentile returns the p-th percentile (0-100) using linear interpolation between the closest ranks,
+// e.g. Percentile(latencies, 99). p is clamped to [0, 100]; the slice is not modified.
+func Percentile[T Number](s []T, p float64) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+
+	return percentileSorted(sorted, p)
+}
+
+func percentileSorted[T Number](sorted []T, p float64) float64 {
+	p = math.Max(0, math.Min(100, p))
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return float64(sorted[lower]) + (rank-float64(lower))*(float64(sorted[upper])-float64(sorted[lower]))
+}
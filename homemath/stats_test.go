@@ -0,0 +1,93 @@
+package homemath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats_Ints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		args       []int
+		wantMean   float64
+		wantMedian float64
+		wantMode   int
+		wantVar    float64
+	}{
+		{
+			name: "Empty slice",
+			args: []int{},
+		},
+		{
+			name:       "Odd length",
+			args:       []int{5, 1, 3, 3, 8},
+			wantMean:   4,
+			wantMedian: 3,
+			wantMode:   3,
+			wantVar:    5.6,
+		},
+		{
+			name:       "Even length",
+			args:       []int{4, 1, 2, 3},
+			wantMean:   2.5,
+			wantMedian: 2.5,
+			wantMode:   4,
+			wantVar:    1.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tt.wantMean, Mean(tt.args...), 1e-9)
+			assert.InDelta(t, tt.wantMedian, Median(tt.args...), 1e-9)
+			assert.Equal(t, tt.wantMode, Mode(tt.args...))
+			assert.InDelta(t, tt.wantVar, Variance(tt.args...), 1e-9)
+		})
+	}
+}
+
+func TestStats_Floats(t *testing.T) {
+	t.Parallel()
+
+	args := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	assert.InDelta(t, 5, Mean(args...), 1e-9)
+	assert.InDelta(t, 4.5, Median(args...), 1e-9)
+	assert.InDelta(t, 4, Mode(args...), 1e-9)
+	assert.InDelta(t, 2, StdDev(args...), 1e-9)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	latencies := []int{100, 10, 40, 30, 20, 60, 50, 80, 70, 90}
+
+	tests := []struct {
+		name string
+		p    float64
+		want float64
+	}{
+		{name: "Min", p: 0, want: 10},
+		{name: "P50", p: 50, want: 55},
+		{name: "P90", p: 90, want: 91},
+		{name: "Max", p: 100, want: 100},
+		{name: "Clamped below", p: -5, want: 10},
+		{name: "Clamped above", p: 150, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tt.want, Percentile(latencies, tt.p), 1e-9)
+		})
+	}
+
+	assert.Equal(t, 100, latencies[0], "input must not be modified")
+	assert.InDelta(t, 0, Percentile([]int{}, 50), 0)
+}
@@ -0,0 +1,110 @@
+package homestorage
+
+import (
+	"sort"
+	"sync"
+)
+
+// Buckets is a thread-safe container of named, independently capped InMemoryStorage instances.
+type Buckets[T any] struct {
+	buckets     map[string]*InMemoryStorage[T]
+	defaultOpts []Option
+
+	mutex sync.RWMutex
+}
+
+// BucketsStats holds aggregated statistics of all buckets.
+type BucketsStats struct {
+	Buckets  int
+	Entries  uint64
+	Capacity uint64
+}
+
+// NewBuckets returns a new instance of Buckets.
+// The given options are applied to every bucket created without its own options.
+func NewBuckets[T any](defaultOpts ...Option) *Buckets[T] {
+	return &Buckets[T]{
+		buckets:     make(map[string]*InMemoryStorage[T]),
+		defaultOpts: defaultOpts,
+		mutex:       sync.RWMutex{},
+	}
+}
+
+// CreateBucket creates a new bucket with the given name and options.
+// If the bucket already exists, ErrAlreadyExists is returned.
+func (b *Buckets[T]) CreateBucket(name string, opts ...Option) (*InMemoryStorage[T], error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.buckets[name]; ok {
+		return nil, ErrAlreadyExists
+	}
+
+	if len(opts) == 0 {
+		opts = b.defaultOpts
+	}
+
+	bucket := NewInMemoryStorage[T](opts...)
+	b.buckets[name] = bucket
+
+	return bucket, nil
+}
+
+// Bucket returns a bucket by the given name.
+// If the bucket is not found, ErrNotFound is returned.
+func (b *Buckets[T]) Bucket(name string) (*InMemoryStorage[T], error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	bucket, ok := b.buckets[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return bucket, nil
+}
+
+// DropBucket removes a bucket with all its elements.
+// If the bucket is not found, ErrNotFound is returned.
+func (b *Buckets[T]) DropBucket(name string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.buckets[name]; !ok {
+		return ErrNotFound
+	}
+
+	delete(b.buckets, name)
+
+	return nil
+}
+
+// Names returns the sorted names of all buckets.
+func (b *Buckets[T]) Names() []string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	names := make([]string, 0, len(b.buckets))
+	for name := range b.buckets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Stats returns aggregated statistics of all buckets.
+func (b *Buckets[T]) Stats() BucketsStats {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stats := BucketsStats{Buckets: len(b.buckets)}
+
+	for _, bucket := range b.buckets {
+		stats.Entries += bucket.Count()
+		stats.Capacity += bucket.capacity
+	}
+
+	return stats
+}
@@ -0,0 +1,42 @@
+package homestorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuckets(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuckets[string](WithCapacity(2))
+
+	users, err := b.CreateBucket("users")
+	require.NoError(t, err)
+
+	sessions, err := b.CreateBucket("sessions", WithCapacity(10))
+	require.NoError(t, err)
+
+	_, err = b.CreateBucket("users")
+	require.ErrorIs(t, err, ErrAlreadyExists)
+
+	require.NoError(t, users.Add("u1", "alice"))
+	require.NoError(t, users.Add("u2", "bob"))
+	require.ErrorIs(t, users.Add("u3", "carol"), ErrCapacityExceeded)
+	require.NoError(t, sessions.Add("s1", "session"))
+
+	got, err := b.Bucket("users")
+	require.NoError(t, err)
+	assert.Same(t, users, got)
+
+	assert.Equal(t, []string{"sessions", "users"}, b.Names())
+	assert.Equal(t, BucketsStats{Buckets: 2, Entries: 3, Capacity: 12}, b.Stats())
+
+	require.NoError(t, b.DropBucket("users"))
+	require.ErrorIs(t, b.DropBucket("users"), ErrNotFound)
+
+	_, err = b.Bucket("users")
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, BucketsStats{Buckets: 1, Entries: 1, Capacity: 10}, b.Stats())
+}
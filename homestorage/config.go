@@ -1,9 +1,24 @@
 package homestorage
 
+import (
+	"time"
+)
+
 const defaultCapacity = 1024
 
 type config struct {
 	capacity uint64
+	ttl      time.Duration
+	sliding  bool
+
+	refreshAfter time.Duration
+	maxBytes     uint64
+	writeBehind  time.Duration
+
+	// cloneFn holds func(T) T, it is type-asserted by the generic storage constructor
+	cloneFn any
+	// sizeFn holds func(T) uint64, it is type-asserted the same way
+	sizeFn any
 }
 
 func newDefaultConfig() *config {
@@ -0,0 +1,152 @@
+package homestorage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// JSONFileBackend is a Backend keeping all values in a single JSON file.
+// The whole file is rewritten on every write, so it suits small data sets only.
+type JSONFileBackend[T any] struct {
+	path   string
+	values map[string]T
+
+	mutex sync.RWMutex
+}
+
+// NewJSONFileBackend returns a new instance of JSONFileBackend reading the file at the given path.
+// A missing file is created on the first write.
+func NewJSONFileBackend[T any](path string) (*JSONFileBackend[T], error) {
+	b := &JSONFileBackend[T]{path: path, values: make(map[string]T)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return b, nil
+		}
+
+		return nil, fmt.Errorf("read storage file: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &b.values); err != nil {
+			return nil, fmt.Errorf("decode storage file %s: %w", path, err)
+		}
+	}
+
+	return b, nil
+}
+
+// Load returns a value by the given key.
+// If the value is not found, ErrNotFound is returned.
+func (b *JSONFileBackend[T]) Load(key string) (T, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	value, ok := b.values[key]
+	if !ok {
+		return value, ErrNotFound
+	}
+
+	return value, nil
+}
+
+// Store creates or replaces a value and rewrites the file.
+func (b *JSONFileBackend[T]) Store(key string, value T) error {
+	return b.WriteBatch(map[string]T{key: value}, nil)
+}
+
+// Delete removes a value and rewrites the file.
+func (b *JSONFileBackend[T]) Delete(key string) error {
+	return b.WriteBatch(nil, []string{key})
+}
+
+// WriteBatch applies all changes and rewrites the file once. The values are left intact if the write fails.
+func (b *JSONFileBackend[T]) WriteBatch(stored map[string]T, deleted []string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	values := maps.Clone(b.values)
+
+	for _, key := range deleted {
+		delete(values, key)
+	}
+
+	for key, value := range stored {
+		values[key] = value
+	}
+
+	if err := b.write(values); err != nil {
+		return err
+	}
+
+	b.values = values
+
+	return nil
+}
+
+// Iterate calls fn for every value in the order of the keys.
+func (b *JSONFileBackend[T]) Iterate(fn func(key string, value T) error) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	keys := make([]string, 0, len(b.values))
+	for key := range b.values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := fn(key, b.values[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// write replaces the file atomically, so it is never left half-written.
+func (b *JSONFileBackend[T]) write(values map[string]T) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode storage file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write storage file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("write storage file: %w", err)
+	}
+
+	// the data must reach the disk before the rename makes it visible
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("sync storage file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write storage file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), b.path); err != nil {
+		return fmt.Errorf("write storage file: %w", err)
+	}
+
+	return nil
+}
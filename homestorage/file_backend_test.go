@@ -0,0 +1,57 @@
+package homestorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFileBackend(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	b, err := NewJSONFileBackend[int](path)
+	require.NoError(t, err)
+
+	require.NoError(t, b.Store("b", 2))
+	require.NoError(t, b.Store("a", 1))
+	require.NoError(t, b.Store("c", 3))
+	require.NoError(t, b.Delete("c"))
+	require.NoError(t, b.Delete("missing"))
+
+	reopened, err := NewJSONFileBackend[int](path)
+	require.NoError(t, err)
+
+	got, err := reopened.Load("a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+
+	_, err = reopened.Load("c")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var keys []string
+
+	require.NoError(t, reopened.Iterate(func(key string, _ int) error {
+		keys = append(keys, key)
+		return nil
+	}))
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must be cleaned up")
+}
+
+func TestJSONFileBackend_Malformed(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "storage.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := NewJSONFileBackend[int](path)
+	require.Error(t, err)
+}
@@ -9,12 +9,33 @@ var (
 	ErrNotFound         = errors.New("element not found")
 	ErrAlreadyExists    = errors.New("element already exists")
 	ErrCapacityExceeded = errors.New("storage capacity exceeded")
+	ErrVersionConflict  = errors.New("element version conflict")
 )
 
+// Cloner is implemented by values that are able to make a deep copy of themselves.
+// InMemoryStorage returns clones of such values on reads.
+type Cloner[T any] interface {
+	Clone() T
+}
+
 // InMemoryStorage is a simple thread-safe in-memory storage that you can use for testing, mocking, etc.
+// Every write assigns the element a new version, which allows optimistic updates with ReplaceIf.
+// With WithMaxBytes, the least recently written elements are evicted to keep the estimated size of the values
+// within the limit.
 type InMemoryStorage[T any] struct {
 	storage  map[string]T
+	versions map[string]uint64
+	cloneFn  func(T) T
 	capacity uint64
+	version  uint64
+
+	sizeFn   func(T) uint64
+	sizes    map[string]uint64
+	maxBytes uint64
+	bytes    uint64
+
+	// persistence is set only for storages created with NewPersistentStorage
+	persistence *persistence[T]
 
 	mutex sync.RWMutex
 }
@@ -28,9 +49,21 @@ func NewInMemoryStorage[T any](opts ...Option) *InMemoryStorage[T] {
 		opt.apply(cfg)
 	}
 
+	cloneFn, _ := cfg.cloneFn.(func(T) T)
+
+	sizeFn, ok := cfg.sizeFn.(func(T) uint64)
+	if !ok {
+		sizeFn = estimateSize[T]
+	}
+
 	return &InMemoryStorage[T]{
 		storage:  make(map[string]T),
+		versions: make(map[string]uint64),
+		cloneFn:  cloneFn,
 		capacity: cfg.capacity,
+		sizeFn:   sizeFn,
+		sizes:    make(map[string]uint64),
+		maxBytes: cfg.maxBytes,
 		mutex:    sync.RWMutex{},
 	}
 }
@@ -42,7 +75,7 @@ func (i *InMemoryStorage[T]) All() []T {
 
 	values := make([]T, 0, len(i.storage))
 	for _, value := range i.storage {
-		values = append(values, value)
+		values = append(values, i.clone(value))
 	}
 
 	return values
@@ -50,7 +83,8 @@ func (i *InMemoryStorage[T]) All() []T {
 
 // Add adds a new element to the storage.
 // If the element with the given key already exists, ErrAlreadyExists is returned.
-// If the storage is full, ErrCapacityExceeded is returned.
+// If the storage is full or the value alone is larger than WithMaxBytes, ErrCapacityExceeded is returned.
+// For a persistent storage, an error of the backend may be returned as well, the element is not added then.
 func (i *InMemoryStorage[T]) Add(key string, value T) error {
 	i.mutex.Lock()
 	defer i.mutex.Unlock()
@@ -63,9 +97,16 @@ func (i *InMemoryStorage[T]) Add(key string, value T) error {
 		return ErrAlreadyExists
 	}
 
-	i.storage[key] = value
+	prev := i.keyState(key)
+
+	size, evicted, err := i.reserve(key, value)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	i.store(key, value, size)
+
+	return i.persistOrRollback(append(evicted, prev)...)
 }
 
 // Get returns an element from the storage by the given key.
@@ -81,18 +122,29 @@ func (i *InMemoryStorage[T]) Get(key string) (T, error) {
 		return defaultVal, ErrNotFound
 	}
 
-	return value, nil
+	return i.clone(value), nil
 }
 
 // Upsert updates an element in the storage by the given key.
 // If the element is not found, it is added to the storage.
+// A value larger than WithMaxBytes is not stored.
 func (i *InMemoryStorage[T]) Upsert(key string, value T) {
 	i.mutex.Lock()
 	defer i.mutex.Unlock()
 
-	i.storage[key] = value
+	size, _, err := i.reserve(key, value)
+	if err != nil {
+		return
+	}
+
+	i.store(key, value, size)
+
+	// a failed write of a persistent storage is retried on the next write or Flush
+	_ = i.persist()
 }
 
+// Replace updates an existing element in the storage by the given key.
+// If the element is not found, ErrNotFound is returned, if the value is larger than WithMaxBytes, ErrCapacityExceeded.
 func (i *InMemoryStorage[T]) Replace(key string, value T) error {
 	i.mutex.Lock()
 	defer i.mutex.Unlock()
@@ -101,9 +153,63 @@ func (i *InMemoryStorage[T]) Replace(key string, value T) error {
 		return ErrNotFound
 	}
 
-	i.storage[key] = value
+	prev := i.keyState(key)
 
-	return nil
+	size, evicted, err := i.reserve(key, value)
+	if err != nil {
+		return err
+	}
+
+	i.store(key, value, size)
+
+	return i.persistOrRollback(append(evicted, prev)...)
+}
+
+// GetVersioned returns an element with its current version by the given key.
+// If the element is not found, ErrNotFound is returned.
+func (i *InMemoryStorage[T]) GetVersioned(key string) (T, uint64, error) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	var defaultVal T
+
+	value, ok := i.storage[key]
+	if !ok {
+		return defaultVal, 0, ErrNotFound
+	}
+
+	return i.clone(value), i.versions[key], nil
+}
+
+// ReplaceIf updates an element only if its version is still expectedVersion and returns the new version.
+// If the element is not found, ErrNotFound is returned, if it was changed since, ErrVersionConflict,
+// if the value is larger than WithMaxBytes, ErrCapacityExceeded.
+func (i *InMemoryStorage[T]) ReplaceIf(key string, value T, expectedVersion uint64) (uint64, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if _, ok := i.storage[key]; !ok {
+		return 0, ErrNotFound
+	}
+
+	if i.versions[key] != expectedVersion {
+		return 0, ErrVersionConflict
+	}
+
+	prev := i.keyState(key)
+
+	size, evicted, err := i.reserve(key, value)
+	if err != nil {
+		return 0, err
+	}
+
+	version := i.store(key, value, size)
+
+	if err = i.persistOrRollback(append(evicted, prev)...); err != nil {
+		return 0, err
+	}
+
+	return version, nil
 }
 
 // Delete deletes an element from the storage by the given key.
@@ -116,9 +222,10 @@ func (i *InMemoryStorage[T]) Delete(key string) error {
 		return ErrNotFound
 	}
 
-	delete(i.storage, key)
+	prev := i.keyState(key)
+	i.remove(key)
 
-	return nil
+	return i.persistOrRollback(prev)
 }
 
 // MustDelete deletes an element from the storage by the given key even if it is not found.
@@ -126,7 +233,8 @@ func (i *InMemoryStorage[T]) MustDelete(key string) {
 	i.mutex.Lock()
 	defer i.mutex.Unlock()
 
-	delete(i.storage, key)
+	i.remove(key)
+	_ = i.persist()
 }
 
 // Clear removes all elements from the storage.
@@ -134,7 +242,15 @@ func (i *InMemoryStorage[T]) Clear() {
 	i.mutex.Lock()
 	defer i.mutex.Unlock()
 
+	for key := range i.storage {
+		i.markDirty(key, false)
+	}
+
 	i.storage = make(map[string]T)
+	i.versions = make(map[string]uint64)
+	i.sizes = make(map[string]uint64)
+	i.bytes = 0
+	_ = i.persist()
 }
 
 // Count returns the number of elements in the storage.
@@ -144,3 +260,88 @@ func (i *InMemoryStorage[T]) Count() uint64 {
 
 	return uint64(len(i.storage))
 }
+
+// Bytes returns the estimated size of the stored values, it is tracked only if WithMaxBytes is set.
+func (i *InMemoryStorage[T]) Bytes() uint64 {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return i.bytes
+}
+
+// reserve returns the size of the value and evicts the least recently written elements until it fits
+// into WithMaxBytes, the states of the evicted elements are returned to roll the eviction back.
+// If the value alone is larger than the limit, ErrCapacityExceeded is returned and nothing is evicted.
+func (i *InMemoryStorage[T]) reserve(key string, value T) (uint64, []keyState[T], error) {
+	if i.maxBytes == 0 {
+		return 0, nil, nil
+	}
+
+	size := i.sizeFn(value)
+	if size > i.maxBytes {
+		return 0, nil, ErrCapacityExceeded
+	}
+
+	var evicted []keyState[T]
+
+	for i.bytes-i.sizes[key]+size > i.maxBytes {
+		var (
+			oldestKey string
+			oldest    uint64
+		)
+
+		for k, version := range i.versions {
+			if k != key && (oldestKey == "" || version < oldest) {
+				oldestKey, oldest = k, version
+			}
+		}
+
+		evicted = append(evicted, i.keyState(oldestKey))
+		i.remove(oldestKey)
+	}
+
+	return size, evicted, nil
+}
+
+// store sets the element with the next version, which is unique within the storage,
+// so a deleted and re-added element never gets its old version back.
+func (i *InMemoryStorage[T]) store(key string, value T, size uint64) uint64 {
+	i.version++
+	i.storage[key] = value
+	i.versions[key] = i.version
+	i.setSize(key, size)
+	i.markDirty(key, true)
+
+	return i.version
+}
+
+func (i *InMemoryStorage[T]) remove(key string) {
+	delete(i.storage, key)
+	delete(i.versions, key)
+	i.setSize(key, 0)
+	i.markDirty(key, false)
+}
+
+func (i *InMemoryStorage[T]) setSize(key string, size uint64) {
+	i.bytes = i.bytes - i.sizes[key] + size
+
+	if size > 0 {
+		i.sizes[key] = size
+	} else {
+		delete(i.sizes, key)
+	}
+}
+
+// clone returns a copy of the value if the storage is configured with a clone function
+// or the value implements Cloner, otherwise the value itself is returned.
+func (i *InMemoryStorage[T]) clone(value T) T {
+	if i.cloneFn != nil {
+		return i.cloneFn(value)
+	}
+
+	if c, ok := any(value).(Cloner[T]); ok {
+		return c.Clone()
+	}
+
+	return value
+}
@@ -399,3 +399,181 @@ func TestInMemoryStorage_Count(t *testing.T) {
 		})
 	}
 }
+
+type clonableSlice []int
+
+func (c clonableSlice) Clone() clonableSlice {
+	return append(clonableSlice(nil), c...)
+}
+
+func TestInMemoryStorage_CloneOnRead(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WithCloneFunc", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewInMemoryStorage[map[string]int](WithCloneFunc(func(m map[string]int) map[string]int {
+			c := make(map[string]int, len(m))
+			for k, v := range m {
+				c[k] = v
+			}
+
+			return c
+		}))
+
+		require.NoError(t, s.Add("key", map[string]int{"a": 1}))
+
+		got, err := s.Get("key")
+		require.NoError(t, err)
+
+		got["a"] = 2
+		s.All()[0]["a"] = 3
+
+		got, err = s.Get("key")
+		require.NoError(t, err)
+		assert.Equal(t, 1, got["a"])
+	})
+
+	t.Run("Cloner", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewInMemoryStorage[clonableSlice]()
+		require.NoError(t, s.Add("key", clonableSlice{1, 2}))
+
+		got, err := s.Get("key")
+		require.NoError(t, err)
+
+		got[0] = 100
+
+		got, err = s.Get("key")
+		require.NoError(t, err)
+		assert.Equal(t, clonableSlice{1, 2}, got)
+	})
+
+	t.Run("Reference semantics by default", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewInMemoryStorage[[]int]()
+		require.NoError(t, s.Add("key", []int{1, 2}))
+
+		got, err := s.Get("key")
+		require.NoError(t, err)
+
+		got[0] = 100
+
+		got, err = s.Get("key")
+		require.NoError(t, err)
+		assert.Equal(t, []int{100, 2}, got)
+	})
+}
+
+func TestInMemoryStorage_MismatchedCloneFunc(t *testing.T) {
+	t.Parallel()
+
+	// a clone func of another type is ignored, the values are returned as is
+	s := NewInMemoryStorage[[]int](WithCloneFunc(func(s []string) []string { return nil }))
+	require.NoError(t, s.Add("key", []int{1}))
+
+	got, err := s.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, got)
+}
+
+func TestInMemoryStorage_MaxBytes(t *testing.T) {
+	t.Parallel()
+
+	s := NewInMemoryStorage[string](WithMaxBytes(6), WithSizeFunc(func(v string) uint64 { return uint64(len(v)) }))
+
+	require.NoError(t, s.Add("a", "aa"))
+	require.NoError(t, s.Add("b", "bb"))
+	require.NoError(t, s.Replace("a", "aa"))
+	require.NoError(t, s.Add("c", "ccc"))
+
+	_, err := s.Get("b")
+	require.ErrorIs(t, err, ErrNotFound, "the least recently written element is evicted")
+	assert.Equal(t, uint64(5), s.Bytes())
+	assert.Equal(t, uint64(2), s.Count())
+
+	require.ErrorIs(t, s.Add("d", "too large"), ErrCapacityExceeded)
+	s.Upsert("a", "too large")
+
+	got, err := s.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "aa", got, "a too large value is not stored")
+
+	s.Upsert("c", "cccccc")
+	assert.Equal(t, uint64(6), s.Bytes())
+	assert.Equal(t, uint64(1), s.Count())
+
+	require.NoError(t, s.Delete("c"))
+	assert.Equal(t, uint64(0), s.Bytes())
+}
+
+func TestInMemoryStorage_ReplaceIf(t *testing.T) {
+	t.Parallel()
+
+	s := NewInMemoryStorage[string]()
+	require.NoError(t, s.Add("key", "v1"))
+
+	value, version, err := s.GetVersioned("key")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", value)
+
+	newVersion, err := s.ReplaceIf("key", "v2", version)
+	require.NoError(t, err)
+	assert.Greater(t, newVersion, version)
+
+	_, err = s.ReplaceIf("key", "v3", version)
+	require.ErrorIs(t, err, ErrVersionConflict)
+
+	_, err = s.ReplaceIf("missing", "v1", 0)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, _, err = s.GetVersioned("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// a re-added element never gets a version seen before
+	require.NoError(t, s.Delete("key"))
+	require.NoError(t, s.Add("key", "v1"))
+
+	_, err = s.ReplaceIf("key", "v2", newVersion)
+	require.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestInMemoryStorage_ReplaceIfConcurrent(t *testing.T) {
+	t.Parallel()
+
+	const workers = 20
+
+	s := NewInMemoryStorage[int]()
+	require.NoError(t, s.Add("counter", 0))
+
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				value, version, err := s.GetVersioned("counter")
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				if _, err = s.ReplaceIf("counter", value+1, version); err == nil {
+					return
+				}
+
+				assert.ErrorIs(t, err, ErrVersionConflict)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	got, err := s.Get("counter")
+	require.NoError(t, err)
+	assert.Equal(t, workers, got)
+}
@@ -0,0 +1,80 @@
+package homestorage
+
+import (
+	"context"
+	"time"
+)
+
+// LoadingStorage is a thread-safe read-through cache: missing or expired elements are obtained
+// from the loader, concurrent loads of the same key are deduplicated.
+type LoadingStorage[T any] struct {
+	store        *TokenStore[T]
+	loader       FetchFunc[T]
+	refreshAfter time.Duration
+}
+
+// NewLoadingStorage returns a new instance of LoadingStorage with the given loader and options.
+// Capacity and TTL options are honored as in TokenStore, WithRefreshAfter enables background refresh.
+// A loaded value that doesn't fit into the storage is still returned, but not cached.
+func NewLoadingStorage[T any](loader FetchFunc[T], opts ...Option) *LoadingStorage[T] {
+	cfg := newDefaultConfig()
+
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	return &LoadingStorage[T]{
+		store:        NewTokenStore[T](opts...),
+		loader:       loader,
+		refreshAfter: cfg.refreshAfter,
+	}
+}
+
+// Get returns an element by the given key, loading it on a miss.
+// A stale element is returned immediately and reloaded in the background; if the reload fails,
+// the element is kept.
+func (l *LoadingStorage[T]) Get(ctx context.Context, key string) (T, error) {
+	s := l.store
+
+	s.mutex.Lock()
+
+	entry, ok := s.lookup(key)
+	if !ok {
+		s.mutex.Unlock()
+
+		return s.GetOrRefresh(ctx, key, l.loader)
+	}
+
+	_, loading := s.inflight[key]
+	if !loading && l.refreshAfter > 0 && s.now().Sub(entry.storedAt) >= l.refreshAfter {
+		call := &fetchCall[T]{done: make(chan struct{})}
+		s.inflight[key] = call
+
+		go s.fetch(ctx, key, l.loader, call)
+	}
+
+	s.mutex.Unlock()
+
+	return entry.value, nil
+}
+
+// Set stores the element with the default TTL, bypassing the loader.
+// If the storage is full, ErrCapacityExceeded is returned.
+func (l *LoadingStorage[T]) Set(key string, value T) error {
+	return l.store.Set(key, value)
+}
+
+// Invalidate removes an element, so the next Get loads it again.
+func (l *LoadingStorage[T]) Invalidate(key string) {
+	l.store.Delete(key)
+}
+
+// Count returns the number of not expired elements in the storage.
+func (l *LoadingStorage[T]) Count() uint64 {
+	return l.store.Count()
+}
+
+// Bytes returns the estimated size of the stored elements, it is tracked only if WithMaxBytes is set.
+func (l *LoadingStorage[T]) Bytes() uint64 {
+	return l.store.Bytes()
+}
@@ -0,0 +1,141 @@
+package homestorage
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadingStorage_Get(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+	s := NewLoadingStorage(func(_ context.Context, key string) (string, error) {
+		calls.Add(1)
+		<-release
+
+		return "value-" + key, nil
+	})
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			got, err := s.Get(context.Background(), "key")
+			assert.NoError(t, err)
+			assert.Equal(t, "value-key", got)
+		}()
+	}
+
+	// let the callers join the in-flight load before it finishes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	got, err := s.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value-key", got)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, uint64(1), s.Count())
+
+	s.Invalidate("key")
+
+	_, err = s.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestLoadingStorage_LoaderError(t *testing.T) {
+	t.Parallel()
+
+	errLoad := errors.New("load failed")
+
+	s := NewLoadingStorage(func(context.Context, string) (int, error) {
+		return 0, errLoad
+	})
+
+	_, err := s.Get(context.Background(), "key")
+	require.ErrorIs(t, err, errLoad)
+	assert.Equal(t, uint64(0), s.Count())
+}
+
+func TestLoadingStorage_Capacity(t *testing.T) {
+	t.Parallel()
+
+	s := NewLoadingStorage(func(_ context.Context, key string) (string, error) {
+		return key, nil
+	}, WithCapacity(1))
+
+	for i := range 3 {
+		got, err := s.Get(context.Background(), strconv.Itoa(i))
+		require.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(i), got)
+	}
+
+	assert.Equal(t, uint64(1), s.Count())
+}
+
+func TestLoadingStorage_BackgroundRefresh(t *testing.T) {
+	t.Parallel()
+
+	var (
+		version atomic.Int32
+		fail    atomic.Bool
+	)
+
+	loaded := make(chan struct{}, 1)
+	clock := &fakeNow{now: time.Now()}
+
+	s := NewLoadingStorage(func(context.Context, string) (int32, error) {
+		defer func() { loaded <- struct{}{} }()
+
+		if fail.Load() {
+			return 0, errors.New("unavailable")
+		}
+
+		return version.Add(1), nil
+	}, WithRefreshAfter(time.Minute))
+	s.store.now = clock.Now
+
+	got, err := s.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), got)
+	<-loaded
+
+	got, _ = s.Get(context.Background(), "key")
+	assert.Equal(t, int32(1), got, "fresh value must not be reloaded")
+
+	clock.Advance(time.Minute)
+
+	got, _ = s.Get(context.Background(), "key")
+	assert.Equal(t, int32(1), got, "stale value is returned while refreshing")
+	<-loaded
+
+	require.Eventually(t, func() bool {
+		got, _ = s.Get(context.Background(), "key")
+		return got == 2
+	}, time.Second, time.Millisecond)
+
+	fail.Store(true)
+	clock.Advance(time.Minute)
+
+	_, _ = s.Get(context.Background(), "key")
+	<-loaded
+
+	got, err = s.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), got, "failed refresh keeps the previous value")
+}
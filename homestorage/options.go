@@ -1,5 +1,9 @@
 package homestorage
 
+import (
+	"time"
+)
+
 type Option interface {
 	apply(cfg *config)
 }
@@ -15,3 +19,62 @@ func WithCapacity(l uint64) Option {
 		cfg.capacity = l
 	})
 }
+
+// WithTTL sets the default time-to-live of the stored elements.
+// Zero means that elements never expire.
+func WithTTL(ttl time.Duration) Option {
+	return optionFn(func(cfg *config) {
+		cfg.ttl = ttl
+	})
+}
+
+// WithSlidingExpiration makes every successful read extend the element's lifetime by its TTL.
+func WithSlidingExpiration() Option {
+	return optionFn(func(cfg *config) {
+		cfg.sliding = true
+	})
+}
+
+// WithRefreshAfter makes LoadingStorage reload elements older than d in the background,
+// while the current value is still returned.
+func WithRefreshAfter(d time.Duration) Option {
+	return optionFn(func(cfg *config) {
+		cfg.refreshAfter = d
+	})
+}
+
+// WithCloneFunc makes the storage return copies of the stored values produced by fn,
+// so callers can't mutate shared state after a read.
+// It applies only to a storage of the element type T, a storage of another type ignores it
+// and falls back to Cloner. Implementing Cloner on the element type has the compiler check the type instead.
+func WithCloneFunc[T any](fn func(T) T) Option {
+	return optionFn(func(cfg *config) {
+		cfg.cloneFn = fn
+	})
+}
+
+// WithMaxBytes bounds the estimated memory used by the stored values.
+// When a new value doesn't fit, the oldest values are evicted. Zero means no limit.
+func WithMaxBytes(n uint64) Option {
+	return optionFn(func(cfg *config) {
+		cfg.maxBytes = n
+	})
+}
+
+// WithSizeFunc sets the function estimating the size of a value in bytes for WithMaxBytes.
+// By default, the size of the JSON encoding of the value is used.
+// It applies only to a storage of the element type T, a storage of another type ignores it
+// and uses the default estimate.
+func WithSizeFunc[T any](fn func(T) uint64) Option {
+	return optionFn(func(cfg *config) {
+		cfg.sizeFn = fn
+	})
+}
+
+// WithWriteBehind makes a storage created with NewPersistentStorage write changes to its backend
+// in the background every interval instead of on every write.
+func WithWriteBehind(interval time.Duration) Option {
+	return optionFn(func(cfg *config) {
+		cfg.writeBehind = interval
+	})
+}
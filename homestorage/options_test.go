@@ -2,8 +2,11 @@ package homestorage
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/vmyroslav/home-lib/hometests"
 )
 
 func TestWithCapacity(t *testing.T) {
@@ -17,3 +20,25 @@ func TestWithCapacity(t *testing.T) {
 		assert.Equal(t, limit, storage.capacity)
 	}
 }
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	hometests.OptionsTest(t, newDefaultConfig, func(cfg *config, o Option) { o.apply(cfg) }, []hometests.OptionCase[config, Option]{
+		{
+			Name:    "defaults",
+			Options: nil,
+			Want:    map[string]any{"capacity": uint64(defaultCapacity), "ttl": time.Duration(0), "sliding": false},
+		},
+		{
+			Name:    "expiration",
+			Options: []Option{WithTTL(time.Minute), WithSlidingExpiration(), WithRefreshAfter(time.Second)},
+			Want:    map[string]any{"ttl": time.Minute, "sliding": true, "refreshAfter": time.Second},
+		},
+		{
+			Name:    "limits",
+			Options: []Option{WithCapacity(10), WithMaxBytes(1 << 20), WithWriteBehind(time.Second)},
+			Want:    map[string]any{"capacity": uint64(10), "maxBytes": uint64(1 << 20), "writeBehind": time.Second},
+		},
+	})
+}
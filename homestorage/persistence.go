@@ -0,0 +1,217 @@
+package homestorage
+
+import (
+	"sync"
+	"time"
+)
+
+// Backend is a durable key-value store an InMemoryStorage can be persisted to.
+type Backend[T any] interface {
+	// Load returns a value by the given key, or ErrNotFound.
+	Load(key string) (T, error)
+	// Store creates or replaces a value.
+	Store(key string, value T) error
+	// Delete removes a value, deleting a missing key is not an error.
+	Delete(key string) error
+	// WriteBatch stores the values and deletes the keys in one write, either all changes are applied or none.
+	// The persistent storage writes all its pending changes with it.
+	WriteBatch(stored map[string]T, deleted []string) error
+	// Iterate calls fn for every stored value until fn returns an error.
+	Iterate(fn func(key string, value T) error) error
+}
+
+type persistence[T any] struct {
+	backend Backend[T]
+	// dirty holds the keys not written to the backend yet, true to store and false to delete the key
+	dirty        map[string]bool
+	writeThrough bool
+
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewPersistentStorage returns a new instance of InMemoryStorage filled with the values of the backend.
+// Every change is written to the backend before the write method returns (write-through),
+// unless WithWriteBehind is used. Close must be called to stop the background writes and flush the changes.
+// If the backend holds more values than the capacity or WithMaxBytes allow, ErrCapacityExceeded is returned.
+// Elements evicted to stay within WithMaxBytes are deleted from the backend as well.
+func NewPersistentStorage[T any](backend Backend[T], opts ...Option) (*InMemoryStorage[T], error) {
+	cfg := newDefaultConfig()
+
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	storage := NewInMemoryStorage[T](opts...)
+
+	err := backend.Iterate(func(key string, value T) error {
+		if len(storage.storage) >= int(storage.capacity) {
+			return ErrCapacityExceeded
+		}
+
+		var size uint64
+
+		if storage.maxBytes > 0 {
+			size = storage.sizeFn(value)
+			if storage.bytes+size > storage.maxBytes {
+				return ErrCapacityExceeded
+			}
+		}
+
+		storage.store(key, value, size)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &persistence[T]{
+		backend:      backend,
+		dirty:        make(map[string]bool),
+		writeThrough: cfg.writeBehind <= 0,
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+	storage.persistence = p
+
+	if p.writeThrough {
+		close(p.stopped)
+	} else {
+		go storage.writeBehind(cfg.writeBehind)
+	}
+
+	return storage, nil
+}
+
+// Flush writes all pending changes to the backend. It is a no-op for a not persistent storage.
+func (i *InMemoryStorage[T]) Flush() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.flush()
+}
+
+// Close stops the background writes and flushes the pending changes.
+// It is a no-op for a not persistent storage.
+func (i *InMemoryStorage[T]) Close() error {
+	if i.persistence == nil {
+		return nil
+	}
+
+	i.persistence.stopOnce.Do(func() { close(i.persistence.stop) })
+	<-i.persistence.stopped
+
+	return i.Flush()
+}
+
+func (i *InMemoryStorage[T]) writeBehind(interval time.Duration) {
+	defer close(i.persistence.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// failed writes stay pending and are retried on the next tick
+			_ = i.Flush()
+		case <-i.persistence.stop:
+			return
+		}
+	}
+}
+
+func (i *InMemoryStorage[T]) markDirty(key string, stored bool) {
+	if i.persistence != nil {
+		i.persistence.dirty[key] = stored
+	}
+}
+
+// persist flushes the pending changes in the write-through mode.
+func (i *InMemoryStorage[T]) persist() error {
+	if i.persistence == nil || !i.persistence.writeThrough {
+		return nil
+	}
+
+	return i.flush()
+}
+
+func (i *InMemoryStorage[T]) flush() error {
+	if i.persistence == nil || len(i.persistence.dirty) == 0 {
+		return nil
+	}
+
+	stored := make(map[string]T)
+
+	var deleted []string
+
+	for key, isStored := range i.persistence.dirty {
+		if isStored {
+			stored[key] = i.storage[key]
+		} else {
+			deleted = append(deleted, key)
+		}
+	}
+
+	// failed changes stay pending and are retried by the next flush
+	if err := i.persistence.backend.WriteBatch(stored, deleted); err != nil {
+		return err
+	}
+
+	clear(i.persistence.dirty)
+
+	return nil
+}
+
+// keyState is the state of a key before a change, to roll the change back if it can't be persisted.
+type keyState[T any] struct {
+	key     string
+	value   T
+	version uint64
+	size    uint64
+	exists  bool
+	dirty   bool
+	stored  bool
+}
+
+func (i *InMemoryStorage[T]) keyState(key string) keyState[T] {
+	state := keyState[T]{key: key, version: i.versions[key], size: i.sizes[key]}
+	state.value, state.exists = i.storage[key]
+
+	if i.persistence != nil {
+		state.stored, state.dirty = i.persistence.dirty[key]
+	}
+
+	return state
+}
+
+// persistOrRollback persists the change of the keys in the write-through mode
+// and restores their previous states if it fails, so the caller can retry the change.
+func (i *InMemoryStorage[T]) persistOrRollback(prevs ...keyState[T]) error {
+	err := i.persist()
+	if err == nil {
+		return nil
+	}
+
+	for _, prev := range prevs {
+		if prev.exists {
+			i.storage[prev.key] = prev.value
+			i.versions[prev.key] = prev.version
+			i.setSize(prev.key, prev.size)
+		} else {
+			delete(i.storage, prev.key)
+			delete(i.versions, prev.key)
+			i.setSize(prev.key, 0)
+		}
+
+		if prev.dirty {
+			i.persistence.dirty[prev.key] = prev.stored
+		} else {
+			delete(i.persistence.dirty, prev.key)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,265 @@
+package homestorage
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingBackend is a Backend in memory that fails writes while fail is set.
+type failingBackend struct {
+	values  map[string]string
+	fail    bool
+	batches int
+	mutex   sync.Mutex
+}
+
+var errBackend = errors.New("backend unavailable")
+
+func (b *failingBackend) Load(key string) (string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	value, ok := b.values[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+func (b *failingBackend) Store(key, value string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.fail {
+		return errBackend
+	}
+
+	b.values[key] = value
+
+	return nil
+}
+
+func (b *failingBackend) Delete(key string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.fail {
+		return errBackend
+	}
+
+	delete(b.values, key)
+
+	return nil
+}
+
+func (b *failingBackend) WriteBatch(stored map[string]string, deleted []string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.batches++
+
+	if b.fail {
+		return errBackend
+	}
+
+	for _, key := range deleted {
+		delete(b.values, key)
+	}
+
+	for key, value := range stored {
+		b.values[key] = value
+	}
+
+	return nil
+}
+
+func (b *failingBackend) Iterate(fn func(key, value string) error) error {
+	for key, value := range b.values {
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *failingBackend) setFail(fail bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.fail = fail
+}
+
+func TestPersistentStorage_WriteThrough(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	backend, err := NewJSONFileBackend[string](path)
+	require.NoError(t, err)
+
+	s, err := NewPersistentStorage[string](backend)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Add("a", "1"))
+	s.Upsert("b", "2")
+	require.NoError(t, s.Replace("a", "3"))
+	s.Upsert("c", "4")
+	require.NoError(t, s.Delete("c"))
+	require.NoError(t, s.Close())
+
+	backend, err = NewJSONFileBackend[string](path)
+	require.NoError(t, err)
+
+	restored, err := NewPersistentStorage[string](backend)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), restored.Count())
+
+	got, err := restored.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "3", got)
+
+	restored.Clear()
+
+	_, err = backend.Load("b")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPersistentStorage_FailedWritesAreRolledBack(t *testing.T) {
+	t.Parallel()
+
+	backend := &failingBackend{values: map[string]string{}}
+
+	s, err := NewPersistentStorage[string](backend)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Add("b", "1"))
+	backend.setFail(true)
+
+	require.ErrorIs(t, s.Add("a", "1"), errBackend)
+	require.ErrorIs(t, s.Replace("b", "2"), errBackend)
+	require.ErrorIs(t, s.Delete("b"), errBackend)
+
+	_, err = s.Get("a")
+	require.ErrorIs(t, err, ErrNotFound, "the failed add is rolled back")
+
+	got, err := s.Get("b")
+	require.NoError(t, err)
+	assert.Equal(t, "1", got, "the failed replace and delete are rolled back")
+
+	backend.setFail(false)
+	require.NoError(t, s.Add("a", "1"), "the add can be retried")
+	require.NoError(t, s.Flush())
+
+	assert.Equal(t, map[string]string{"a": "1", "b": "1"}, backend.values)
+}
+
+func TestPersistentStorage_FailedEvictionsAreRolledBack(t *testing.T) {
+	t.Parallel()
+
+	backend := &failingBackend{values: map[string]string{}}
+
+	s, err := NewPersistentStorage[string](backend, WithMaxBytes(4), WithSizeFunc(func(v string) uint64 { return uint64(len(v)) }))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Add("a", "aa"))
+	require.NoError(t, s.Add("b", "bb"))
+	backend.setFail(true)
+
+	require.ErrorIs(t, s.Add("c", "cc"), errBackend)
+	assert.ElementsMatch(t, []string{"aa", "bb"}, s.All(), "the eviction is rolled back")
+	assert.Equal(t, uint64(4), s.Bytes())
+
+	backend.setFail(false)
+	require.NoError(t, s.Add("c", "cc"))
+	assert.Equal(t, map[string]string{"b": "bb", "c": "cc"}, backend.values, "the evicted element is deleted")
+
+	_, err = NewPersistentStorage[string](&failingBackend{values: map[string]string{"a": "aaa", "b": "bbb"}}, WithMaxBytes(4),
+		WithSizeFunc(func(v string) uint64 { return uint64(len(v)) }))
+	require.ErrorIs(t, err, ErrCapacityExceeded)
+}
+
+func TestPersistentStorage_FailedUpsertsAreRetried(t *testing.T) {
+	t.Parallel()
+
+	backend := &failingBackend{values: map[string]string{}, fail: true}
+
+	s, err := NewPersistentStorage[string](backend)
+	require.NoError(t, err)
+
+	s.Upsert("a", "1")
+
+	got, err := s.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", got, "the change is kept in memory")
+
+	backend.setFail(false)
+	require.NoError(t, s.Flush())
+
+	got, err = backend.Load("a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", got)
+}
+
+func TestPersistentStorage_FlushWritesOneBatch(t *testing.T) {
+	t.Parallel()
+
+	backend := &failingBackend{values: map[string]string{"a": "1", "b": "2", "c": "3"}}
+
+	s, err := NewPersistentStorage[string](backend, WithWriteBehind(time.Hour))
+	require.NoError(t, err)
+
+	s.Upsert("d", "4")
+	s.Clear()
+	s.Upsert("e", "5")
+	require.NoError(t, s.Close())
+
+	assert.Equal(t, 1, backend.batches)
+	assert.Equal(t, map[string]string{"e": "5"}, backend.values)
+}
+
+func TestPersistentStorage_WriteBehind(t *testing.T) {
+	t.Parallel()
+
+	backend := &failingBackend{values: map[string]string{}}
+
+	s, err := NewPersistentStorage[string](backend, WithWriteBehind(10*time.Millisecond))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Add("a", "1"))
+
+	_, err = backend.Load("a")
+	require.ErrorIs(t, err, ErrNotFound, "writes are deferred")
+
+	require.Eventually(t, func() bool {
+		_, err = backend.Load("a")
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	s.Upsert("b", "2")
+	require.NoError(t, s.Close())
+	require.NoError(t, s.Close())
+
+	_, err = backend.Load("b")
+	require.NoError(t, err, "close flushes pending writes")
+}
+
+func TestPersistentStorage_Capacity(t *testing.T) {
+	t.Parallel()
+
+	backend := &failingBackend{values: map[string]string{"a": "1", "b": "2"}}
+
+	_, err := NewPersistentStorage[string](backend, WithCapacity(1))
+	require.ErrorIs(t, err, ErrCapacityExceeded)
+
+	s := NewInMemoryStorage[string]()
+	require.NoError(t, s.Flush())
+	require.NoError(t, s.Close())
+}
@@ -0,0 +1,129 @@
+package homestorage
+
+import (
+	"context"
+	"math"
+	"sync"
+)
+
+// Queue is a thread-safe bounded FIFO queue.
+// Push never blocks: if the queue is full, ErrCapacityExceeded is returned.
+// The memory grows with the number of queued elements, not with the capacity.
+type Queue[T any] struct {
+	items    []T
+	capacity uint64
+
+	notify chan struct{}
+	mutex  sync.Mutex
+}
+
+// NewQueue returns a new instance of Queue with the given options.
+// The default capacity is 1024.
+func NewQueue[T any](opts ...Option) *Queue[T] {
+	cfg := newDefaultConfig()
+
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	return &Queue[T]{
+		capacity: cfg.capacity,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Push adds a new element to the end of the queue.
+// If the queue is full, ErrCapacityExceeded is returned.
+func (q *Queue[T]) Push(value T) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if uint64(len(q.items)) >= q.capacity {
+		return ErrCapacityExceeded
+	}
+
+	q.items = append(q.items, value)
+	q.signal()
+
+	return nil
+}
+
+// Pop removes and returns the first element of the queue.
+// It blocks until an element is available or the context is done.
+func (q *Queue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		if value, ok := q.TryPop(); ok {
+			return value, nil
+		}
+
+		select {
+		case <-q.notify:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryPop removes and returns the first element of the queue without blocking.
+// The second return value is false if the queue is empty.
+func (q *Queue[T]) TryPop() (T, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var zero T
+
+	if len(q.items) == 0 {
+		return zero, false
+	}
+
+	value := q.items[0]
+	q.items[0] = zero
+	q.items = q.items[1:]
+
+	// wake up the next waiter if there is something left to consume
+	if len(q.items) > 0 {
+		q.signal()
+	}
+
+	return value, true
+}
+
+// Drain removes all elements from the queue and returns them in FIFO order.
+func (q *Queue[T]) Drain() []T {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	values := q.items
+	q.items = nil
+
+	if values == nil {
+		values = []T{}
+	}
+
+	return values
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.items)
+}
+
+// Cap returns the capacity of the queue, capped at math.MaxInt.
+func (q *Queue[T]) Cap() int {
+	if q.capacity > math.MaxInt {
+		return math.MaxInt
+	}
+
+	return int(q.capacity)
+}
+
+func (q *Queue[T]) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,108 @@
+package homestorage
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_PushPop(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[int](WithCapacity(3))
+
+	require.NoError(t, q.Push(1))
+	require.NoError(t, q.Push(2))
+	require.NoError(t, q.Push(3))
+	require.ErrorIs(t, q.Push(4), ErrCapacityExceeded)
+
+	assert.Equal(t, 3, q.Len())
+	assert.Equal(t, 3, q.Cap())
+
+	for _, want := range []int{1, 2, 3} {
+		got, ok := q.TryPop()
+		require.True(t, ok)
+		assert.Equal(t, want, got)
+	}
+
+	_, ok := q.TryPop()
+	assert.False(t, ok)
+}
+
+func TestQueue_PopBlocks(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[string]()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+
+		_ = q.Push("value")
+	}()
+
+	got, err := q.Pop(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "value", got)
+}
+
+func TestQueue_PopContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[string]()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := q.Pop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQueue_Drain(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[int](WithCapacity(10))
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, q.Push(i))
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, q.Drain())
+	assert.Equal(t, 0, q.Len())
+	assert.Empty(t, q.Drain())
+}
+
+func TestQueue_ConcurrentPush(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[int](WithCapacity(100))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) { //nolint:wsl
+			defer wg.Done()
+
+			_ = q.Push(i)
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 100, q.Len())
+}
+
+func TestQueue_LargeCapacity(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[int](WithCapacity(math.MaxUint64))
+
+	require.NoError(t, q.Push(1))
+	assert.Equal(t, 1, q.Len())
+	assert.Equal(t, math.MaxInt, q.Cap())
+}
@@ -3,7 +3,10 @@ package homestorage
 import (
 	"math"
 	"math/rand"
+	"sync"
 	"time"
+
+	"github.com/vmyroslav/home-lib/homemath"
 )
 
 type Item[T any] struct {
@@ -14,38 +17,57 @@ type Item[T any] struct {
 type WeightedRandomSelector[T any] struct {
 	items       []Item[T]
 	prioritySum uint32
+
+	// expiresAt is aligned with items, zero means that the item never expires
+	expiresAt []time.Time
+	expiring  int
+	now       func() time.Time
+
+	rnd homemath.Randomizer
+
+	// Get drops the expired items, so it changes the selector as well
+	mutex sync.Mutex
 }
 
 // NewWeightedRandomSelector creates a new instance of WeightedRandomSelector for a specific type.
 func NewWeightedRandomSelector[T any]() *WeightedRandomSelector[T] {
-	return &WeightedRandomSelector[T]{}
+	return &WeightedRandomSelector[T]{now: time.Now}
+}
+
+// WithRandomizer makes the selector pick items with the given source of randomness,
+// e.g. a seeded one to get reproducible picks in tests.
+func (wrs *WeightedRandomSelector[T]) WithRandomizer(r homemath.Randomizer) *WeightedRandomSelector[T] {
+	wrs.rnd = r
+
+	return wrs
 }
 
 // AddItem adds a new item to the selector.
 func (wrs *WeightedRandomSelector[T]) AddItem(item Item[T]) {
-	wrs.items = append(wrs.items, item)
-	wrs.prioritySum += uint32(item.PriorityWeight)
+	wrs.add(item, time.Time{})
 }
 
 // Add adds a new item to the selector with a specific priority.
 func (wrs *WeightedRandomSelector[T]) Add(value T, priority uint16) {
-	wrs.items = append(wrs.items, Item[T]{Value: value, PriorityWeight: priority})
-	wrs.prioritySum += uint32(priority)
+	wrs.add(Item[T]{Value: value, PriorityWeight: priority}, time.Time{})
+}
+
+// AddWithTTL adds a new item to the selector with a specific priority, the item is dropped after ttl.
+func (wrs *WeightedRandomSelector[T]) AddWithTTL(value T, priority uint16, ttl time.Duration) {
+	wrs.add(Item[T]{Value: value, PriorityWeight: priority}, wrs.currentTime().Add(ttl))
 }
 
 // AddMany adds multiple items to the selector.
 func (wrs *WeightedRandomSelector[T]) AddMany(items []Item[T]) {
 	for _, item := range items {
-		wrs.items = append(wrs.items, item)
-		wrs.prioritySum += uint32(item.PriorityWeight)
+		wrs.add(item, time.Time{})
 	}
 }
 
 // AddOrdered adds multiple items to the selector with their priorities based on their order.
 func (wrs *WeightedRandomSelector[T]) AddOrdered(values []T) {
 	for i, value := range values {
-		wrs.items = append(wrs.items, Item[T]{Value: value, PriorityWeight: uint16(i)})
-		wrs.prioritySum += uint32(i)
+		wrs.add(Item[T]{Value: value, PriorityWeight: uint16(i)}, time.Time{})
 	}
 }
 
@@ -59,17 +81,21 @@ func (wrs *WeightedRandomSelector[T]) AddTopPrioElement(value T) {
 func (wrs *WeightedRandomSelector[T]) Get() (T, bool) {
 	var zero T
 
+	wrs.mutex.Lock()
+	defer wrs.mutex.Unlock()
+
+	wrs.removeExpired()
+
 	if len(wrs.items) == 0 {
 		return zero, false
 	}
 
 	if wrs.prioritySum == 0 {
 		// If total sum of priorities is 0, select an item randomly without considering the priorities
-		return wrs.items[rand.Intn(len(wrs.items))].Value, true //nolint:gosec
+		return wrs.items[wrs.randomizer().Intn(len(wrs.items))].Value, true
 	}
 
-	rs := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
-	pick := uint32(rs.Intn(int(wrs.prioritySum)))
+	pick := uint32(wrs.randomizer().Intn(int(wrs.prioritySum)))
 
 	current := uint32(0)
 	for _, item := range wrs.items {
@@ -81,3 +107,57 @@ func (wrs *WeightedRandomSelector[T]) Get() (T, bool) {
 
 	return zero, false
 }
+
+func (wrs *WeightedRandomSelector[T]) add(item Item[T], expiresAt time.Time) {
+	wrs.mutex.Lock()
+	defer wrs.mutex.Unlock()
+
+	if !expiresAt.IsZero() {
+		wrs.expiring++
+	}
+
+	wrs.items = append(wrs.items, item)
+	wrs.expiresAt = append(wrs.expiresAt, expiresAt)
+	wrs.prioritySum += uint32(item.PriorityWeight)
+}
+
+// removeExpired drops the expired items, it is a no-op if no item was added with a TTL.
+func (wrs *WeightedRandomSelector[T]) removeExpired() {
+	if wrs.expiring == 0 {
+		return
+	}
+
+	now := wrs.currentTime()
+	items, expiresAt := wrs.items[:0], wrs.expiresAt[:0]
+
+	for i, item := range wrs.items {
+		if exp := wrs.expiresAt[i]; !exp.IsZero() && !now.Before(exp) {
+			wrs.prioritySum -= uint32(item.PriorityWeight)
+			wrs.expiring--
+
+			continue
+		}
+
+		items = append(items, item)
+		expiresAt = append(expiresAt, wrs.expiresAt[i])
+	}
+
+	clear(wrs.items[len(items):])
+	wrs.items, wrs.expiresAt = items, expiresAt
+}
+
+func (wrs *WeightedRandomSelector[T]) currentTime() time.Time {
+	if wrs.now == nil {
+		return time.Now()
+	}
+
+	return wrs.now()
+}
+
+func (wrs *WeightedRandomSelector[T]) randomizer() homemath.Randomizer {
+	if wrs.rnd != nil {
+		return wrs.rnd
+	}
+
+	return rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+}
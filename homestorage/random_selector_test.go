@@ -2,8 +2,13 @@ package homestorage
 
 import (
 	"math"
+	"math/rand"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestWeightedRandomSelector(t *testing.T) {
@@ -120,3 +125,106 @@ func TestAddItem(t *testing.T) {
 		t.Errorf("Test AddItem failed: expected %v, got %v", want, selector.items)
 	}
 }
+
+func TestWeightedRandomSelector_AddWithTTL(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeNow{now: time.Now()}
+	wrs := NewWeightedRandomSelector[string]()
+	wrs.now = clock.Now
+
+	wrs.Add("stable", 1)
+	wrs.AddWithTTL("canary", math.MaxUint16, time.Minute)
+	wrs.AddWithTTL("short", 1, time.Second)
+
+	got, ok := wrs.Get()
+	assert.True(t, ok)
+	assert.NotEmpty(t, got)
+	assert.Len(t, wrs.items, 3)
+
+	clock.Advance(time.Second)
+	wrs.Get()
+
+	assert.Len(t, wrs.items, 2)
+	assert.Equal(t, uint32(math.MaxUint16)+1, wrs.prioritySum)
+
+	clock.Advance(time.Minute)
+
+	for range 10 {
+		got, ok = wrs.Get()
+		assert.True(t, ok)
+		assert.Equal(t, "stable", got)
+	}
+
+	assert.Equal(t, uint32(1), wrs.prioritySum)
+	assert.Zero(t, wrs.expiring)
+}
+
+func TestWeightedRandomSelector_ConcurrentGet(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeNow{now: time.Now()}
+	wrs := NewWeightedRandomSelector[int]()
+	wrs.now = clock.Now
+
+	wrs.Add(0, 1)
+
+	for i := 1; i <= 100; i++ {
+		wrs.AddWithTTL(i, 1, time.Duration(i)*time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				clock.Advance(time.Millisecond)
+
+				_, ok := wrs.Get()
+				assert.True(t, ok)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	got, ok := wrs.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, got)
+	assert.Equal(t, uint32(1), wrs.prioritySum)
+}
+
+func TestWeightedRandomSelector_AllExpired(t *testing.T) {
+	t.Parallel()
+
+	var wrs WeightedRandomSelector[int]
+
+	wrs.AddWithTTL(1, 1, -time.Second)
+
+	_, ok := wrs.Get()
+	assert.False(t, ok)
+}
+
+func TestWeightedRandomSelector_WithRandomizer(t *testing.T) {
+	t.Parallel()
+
+	picks := func() []string {
+		wrs := NewWeightedRandomSelector[string]().WithRandomizer(rand.New(rand.NewSource(1))) //nolint:gosec
+		wrs.AddOrdered([]string{"a", "b", "c", "d"})
+
+		var out []string
+
+		for range 20 {
+			v, _ := wrs.Get()
+			out = append(out, v)
+		}
+
+		return out
+	}
+
+	assert.Equal(t, picks(), picks())
+}
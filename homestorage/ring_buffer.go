@@ -0,0 +1,172 @@
+package homestorage
+
+import (
+	"context"
+	"math"
+	"sync"
+)
+
+const minRingBufferSize = 16
+
+// RingBuffer is a thread-safe fixed-size circular buffer.
+// When the buffer is full, Push overwrites the oldest element.
+// The memory grows with the number of buffered elements up to the capacity, it isn't allocated upfront.
+type RingBuffer[T any] struct {
+	items    []T
+	head     int
+	length   int
+	capacity int
+
+	notify chan struct{}
+	mutex  sync.Mutex
+}
+
+// NewRingBuffer returns a new instance of RingBuffer with the given options.
+// The default capacity is 1024.
+func NewRingBuffer[T any](opts ...Option) *RingBuffer[T] {
+	cfg := newDefaultConfig()
+
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	capacity := math.MaxInt
+	if cfg.capacity < math.MaxInt {
+		capacity = int(cfg.capacity)
+	}
+
+	return &RingBuffer[T]{
+		capacity: capacity,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Push adds a new element to the buffer.
+// If the buffer is full, the oldest element is overwritten and returned with true.
+func (r *RingBuffer[T]) Push(value T) (T, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var (
+		overwritten T
+		full        bool
+	)
+
+	if r.capacity == 0 {
+		return value, true
+	}
+
+	if r.length == len(r.items) && r.length < r.capacity {
+		r.grow()
+	}
+
+	if r.length == len(r.items) {
+		overwritten, full = r.items[r.head], true
+		r.items[r.head] = value
+		r.head = (r.head + 1) % len(r.items)
+	} else {
+		r.items[(r.head+r.length)%len(r.items)] = value
+		r.length++
+	}
+
+	r.signal()
+
+	return overwritten, full
+}
+
+// Pop removes and returns the oldest element of the buffer.
+// It blocks until an element is available or the context is done.
+func (r *RingBuffer[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		if value, ok := r.TryPop(); ok {
+			return value, nil
+		}
+
+		select {
+		case <-r.notify:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryPop removes and returns the oldest element of the buffer without blocking.
+// The second return value is false if the buffer is empty.
+func (r *RingBuffer[T]) TryPop() (T, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var zero T
+
+	if r.length == 0 {
+		return zero, false
+	}
+
+	value := r.items[r.head]
+	r.items[r.head] = zero
+	r.head = (r.head + 1) % len(r.items)
+	r.length--
+
+	// wake up the next waiter if there is something left to consume
+	if r.length > 0 {
+		r.signal()
+	}
+
+	return value, true
+}
+
+// Drain removes all elements from the buffer and returns them from the oldest to the newest.
+func (r *RingBuffer[T]) Drain() []T {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var zero T
+
+	values := make([]T, 0, r.length)
+	for ; r.length > 0; r.length-- {
+		values = append(values, r.items[r.head])
+		r.items[r.head] = zero
+		r.head = (r.head + 1) % len(r.items)
+	}
+
+	r.head = 0
+
+	return values
+}
+
+// Len returns the number of elements in the buffer.
+func (r *RingBuffer[T]) Len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.length
+}
+
+// Cap returns the capacity of the buffer, capped at math.MaxInt.
+func (r *RingBuffer[T]) Cap() int {
+	return r.capacity
+}
+
+// grow doubles the backing slice up to the capacity, moving the elements to its start.
+func (r *RingBuffer[T]) grow() {
+	size := min(max(2*len(r.items), minRingBufferSize), r.capacity)
+	if size < len(r.items) { // overflow
+		size = r.capacity
+	}
+
+	items := make([]T, size)
+	for i := 0; i < r.length; i++ {
+		items[i] = r.items[(r.head+i)%len(r.items)]
+	}
+
+	r.items = items
+	r.head = 0
+}
+
+func (r *RingBuffer[T]) signal() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,143 @@
+package homestorage
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBuffer_PushOverwritesOldest(t *testing.T) {
+	t.Parallel()
+
+	r := NewRingBuffer[int](WithCapacity(3))
+
+	for i := 1; i <= 3; i++ {
+		_, overwritten := r.Push(i)
+		require.False(t, overwritten)
+	}
+
+	old, overwritten := r.Push(4)
+	require.True(t, overwritten)
+	assert.Equal(t, 1, old)
+
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, 3, r.Cap())
+	assert.Equal(t, []int{2, 3, 4}, r.Drain())
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestRingBuffer_GrowsLazily(t *testing.T) {
+	t.Parallel()
+
+	r := NewRingBuffer[int](WithCapacity(math.MaxUint64))
+	assert.Equal(t, math.MaxInt, r.Cap())
+	assert.Empty(t, r.items)
+
+	// the pops make the buffer wrap around its backing slice before it grows, the order has to be kept
+	var want []int
+
+	for i := 0; i < 40; i++ {
+		_, overwritten := r.Push(i)
+		require.False(t, overwritten)
+
+		want = append(want, i)
+
+		if i%3 == 0 {
+			value, ok := r.TryPop()
+			require.True(t, ok)
+			assert.Equal(t, want[0], value)
+
+			want = want[1:]
+		}
+	}
+
+	assert.Less(t, len(r.items), 64)
+	assert.Equal(t, want, r.Drain())
+}
+
+func TestRingBuffer_TryPop(t *testing.T) {
+	t.Parallel()
+
+	r := NewRingBuffer[string](WithCapacity(2))
+
+	_, ok := r.TryPop()
+	require.False(t, ok)
+
+	r.Push("a")
+	r.Push("b")
+	r.Push("c")
+
+	got, ok := r.TryPop()
+	require.True(t, ok)
+	assert.Equal(t, "b", got)
+
+	got, ok = r.TryPop()
+	require.True(t, ok)
+	assert.Equal(t, "c", got)
+
+	_, ok = r.TryPop()
+	assert.False(t, ok)
+}
+
+func TestRingBuffer_PopBlocks(t *testing.T) {
+	t.Parallel()
+
+	r := NewRingBuffer[int](WithCapacity(4))
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		r.Push(42)
+	}()
+
+	got, err := r.Pop(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 42, got)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = r.Pop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRingBuffer_ConcurrentPop(t *testing.T) {
+	t.Parallel()
+
+	r := NewRingBuffer[int](WithCapacity(100))
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		got   []int
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() { //nolint:wsl
+			defer wg.Done()
+
+			v, err := r.Pop(context.Background())
+			if err != nil {
+				return
+			}
+
+			mutex.Lock()
+			got = append(got, v)
+			mutex.Unlock()
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		r.Push(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, got, 10)
+	assert.Equal(t, 0, r.Len())
+}
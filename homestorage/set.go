@@ -0,0 +1,112 @@
+package homestorage
+
+import (
+	"sync"
+)
+
+// Set is a thread-safe set of comparable values.
+type Set[T comparable] struct {
+	items map[T]struct{}
+
+	mutex sync.RWMutex
+}
+
+// NewSet returns a new instance of Set containing the given values.
+func NewSet[T comparable](values ...T) *Set[T] {
+	s := &Set[T]{
+		items: make(map[T]struct{}, len(values)),
+		mutex: sync.RWMutex{},
+	}
+
+	for _, value := range values {
+		s.items[value] = struct{}{}
+	}
+
+	return s
+}
+
+// Add adds values to the set.
+func (s *Set[T]) Add(values ...T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, value := range values {
+		s.items[value] = struct{}{}
+	}
+}
+
+// Remove removes values from the set.
+func (s *Set[T]) Remove(values ...T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, value := range values {
+		delete(s.items, value)
+	}
+}
+
+// Contains reports whether the value is in the set.
+func (s *Set[T]) Contains(value T) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, ok := s.items[value]
+
+	return ok
+}
+
+// Union returns a new set with the values present in either s or other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := NewSet(other.ToSlice()...)
+	result.Add(s.ToSlice()...)
+
+	return result
+}
+
+// Intersection returns a new set with the values present in both s and other.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	// take a snapshot first so that both sets are never locked at the same time
+	otherValues := other.ToSlice()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := NewSet[T]()
+
+	for _, value := range otherValues {
+		if _, ok := s.items[value]; ok {
+			result.items[value] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// ToSlice returns all values of the set in no particular order.
+func (s *Set[T]) ToSlice() []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	values := make([]T, 0, len(s.items))
+	for value := range s.items {
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.items)
+}
+
+// Clear removes all values from the set.
+func (s *Set[T]) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.items = make(map[T]struct{})
+}
@@ -0,0 +1,96 @@
+package homestorage
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_AddRemoveContains(t *testing.T) {
+	t.Parallel()
+
+	s := NewSet("a", "b")
+	s.Add("c", "a")
+
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.Contains("a"))
+	assert.True(t, s.Contains("c"))
+	assert.False(t, s.Contains("d"))
+
+	s.Remove("a", "d")
+
+	assert.False(t, s.Contains("a"))
+	assert.Equal(t, 2, s.Len())
+
+	s.Clear()
+
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestSet_UnionIntersection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		left             []int
+		right            []int
+		wantUnion        []int
+		wantIntersection []int
+	}{
+		{
+			name:             "Empty sets",
+			left:             []int{},
+			right:            []int{},
+			wantUnion:        []int{},
+			wantIntersection: []int{},
+		},
+		{
+			name:             "Disjoint sets",
+			left:             []int{1, 2},
+			right:            []int{3, 4},
+			wantUnion:        []int{1, 2, 3, 4},
+			wantIntersection: []int{},
+		},
+		{
+			name:             "Overlapping sets",
+			left:             []int{1, 2, 3},
+			right:            []int{2, 3, 4},
+			wantUnion:        []int{1, 2, 3, 4},
+			wantIntersection: []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			left, right := NewSet(tt.left...), NewSet(tt.right...)
+
+			assert.ElementsMatch(t, tt.wantUnion, left.Union(right).ToSlice())
+			assert.ElementsMatch(t, tt.wantIntersection, left.Intersection(right).ToSlice())
+		})
+	}
+}
+
+func TestSet_ConcurrentAdd(t *testing.T) {
+	t.Parallel()
+
+	s := NewSet[int]()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) { //nolint:wsl
+			defer wg.Done()
+
+			s.Add(i)
+			_ = s.Contains(i)
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 100, s.Len())
+}
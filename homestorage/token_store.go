@@ -0,0 +1,284 @@
+package homestorage
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// FetchFunc fetches a fresh value for the given key.
+type FetchFunc[T any] func(ctx context.Context, key string) (T, error)
+
+// TokenStore is a thread-safe keyed store of expiring values, e.g. access tokens or sessions.
+// Concurrent refreshes of the same key are deduplicated, so fetch is called only once.
+type TokenStore[T any] struct {
+	entries  map[string]tokenEntry[T]
+	inflight map[string]*fetchCall[T]
+	now      func() time.Time
+
+	capacity uint64
+	ttl      time.Duration
+	sliding  bool
+
+	sizeFn   func(T) uint64
+	maxBytes uint64
+	bytes    uint64
+
+	mutex sync.Mutex
+}
+
+type tokenEntry[T any] struct {
+	expiresAt time.Time
+	storedAt  time.Time
+	value     T
+	ttl       time.Duration
+	size      uint64
+}
+
+type fetchCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// NewTokenStore returns a new instance of TokenStore with the given options.
+// The default capacity is 1024, by default elements never expire.
+func NewTokenStore[T any](opts ...Option) *TokenStore[T] {
+	cfg := newDefaultConfig()
+
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	sizeFn, ok := cfg.sizeFn.(func(T) uint64)
+	if !ok {
+		sizeFn = estimateSize[T]
+	}
+
+	return &TokenStore[T]{
+		entries:  make(map[string]tokenEntry[T]),
+		inflight: make(map[string]*fetchCall[T]),
+		now:      time.Now,
+		capacity: cfg.capacity,
+		ttl:      cfg.ttl,
+		sliding:  cfg.sliding,
+		sizeFn:   sizeFn,
+		maxBytes: cfg.maxBytes,
+	}
+}
+
+// Set stores the value with the default TTL.
+// If the storage is full, ErrCapacityExceeded is returned.
+func (s *TokenStore[T]) Set(key string, value T) error {
+	return s.SetWithTTL(key, value, s.ttl)
+}
+
+// SetWithTTL stores the value with the given TTL. Zero TTL means that the value never expires.
+// If the storage is full, ErrCapacityExceeded is returned.
+func (s *TokenStore[T]) SetWithTTL(key string, value T, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.set(key, value, ttl)
+}
+
+// Get returns a not expired value by the given key.
+// If the value is not found or expired, ErrNotFound is returned.
+func (s *TokenStore[T]) Get(key string) (T, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.get(key)
+}
+
+// GetOrRefresh returns a not expired value by the given key or calls fetch to obtain a new one.
+// Concurrent callers for the same key wait for a single fetch call and share its result.
+func (s *TokenStore[T]) GetOrRefresh(ctx context.Context, key string, fetch FetchFunc[T]) (T, error) {
+	s.mutex.Lock()
+
+	if value, err := s.get(key); err == nil {
+		s.mutex.Unlock()
+
+		return value, nil
+	}
+
+	call, ok := s.inflight[key]
+	if !ok {
+		call = &fetchCall[T]{done: make(chan struct{})}
+		s.inflight[key] = call
+
+		go s.fetch(ctx, key, fetch, call)
+	}
+
+	s.mutex.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Delete removes the value by the given key.
+func (s *TokenStore[T]) Delete(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.remove(key)
+}
+
+// Count returns the number of not expired values in the storage.
+func (s *TokenStore[T]) Count() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.purgeExpired()
+
+	return uint64(len(s.entries))
+}
+
+// Bytes returns the estimated size of the stored values, it is tracked only if WithMaxBytes is set.
+func (s *TokenStore[T]) Bytes() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.bytes
+}
+
+func (s *TokenStore[T]) fetch(ctx context.Context, key string, fetch FetchFunc[T], call *fetchCall[T]) {
+	// the fetch is shared between callers, so it must not be canceled by the first one
+	call.value, call.err = fetch(context.WithoutCancel(ctx), key)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if call.err == nil {
+		_ = s.set(key, call.value, s.ttl)
+	}
+
+	delete(s.inflight, key)
+	close(call.done)
+}
+
+func (s *TokenStore[T]) get(key string) (T, error) {
+	entry, ok := s.lookup(key)
+	if !ok {
+		var zero T
+		return zero, ErrNotFound
+	}
+
+	return entry.value, nil
+}
+
+// lookup returns a not expired entry by the given key, extending its lifetime if sliding expiration is on.
+func (s *TokenStore[T]) lookup(key string) (tokenEntry[T], bool) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return entry, false
+	}
+
+	if s.expired(entry) {
+		s.remove(key)
+
+		return tokenEntry[T]{}, false
+	}
+
+	if s.sliding && entry.ttl > 0 {
+		entry.expiresAt = s.now().Add(entry.ttl)
+		s.entries[key] = entry
+	}
+
+	return entry, true
+}
+
+func (s *TokenStore[T]) set(key string, value T, ttl time.Duration) error {
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= int(s.capacity) {
+		s.purgeExpired()
+
+		if len(s.entries) >= int(s.capacity) {
+			return ErrCapacityExceeded
+		}
+	}
+
+	var size uint64
+
+	if s.maxBytes > 0 {
+		size = s.sizeFn(value)
+		if size > s.maxBytes {
+			return ErrCapacityExceeded
+		}
+
+		s.remove(key)
+		s.evictBytes(size)
+	}
+
+	now := s.now()
+
+	entry := tokenEntry[T]{value: value, ttl: ttl, storedAt: now, size: size}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	s.entries[key] = entry
+	s.bytes += size
+
+	return nil
+}
+
+// evictBytes frees space for a value of the given size, dropping expired values first, then the oldest ones.
+func (s *TokenStore[T]) evictBytes(size uint64) {
+	if s.bytes+size <= s.maxBytes {
+		return
+	}
+
+	s.purgeExpired()
+
+	for s.bytes+size > s.maxBytes {
+		var (
+			oldestKey string
+			oldest    time.Time
+		)
+
+		for key, entry := range s.entries {
+			if oldestKey == "" || entry.storedAt.Before(oldest) {
+				oldestKey, oldest = key, entry.storedAt
+			}
+		}
+
+		s.remove(oldestKey)
+	}
+}
+
+func (s *TokenStore[T]) remove(key string) {
+	if entry, ok := s.entries[key]; ok {
+		s.bytes -= entry.size
+		delete(s.entries, key)
+	}
+}
+
+func (s *TokenStore[T]) purgeExpired() {
+	for key, entry := range s.entries {
+		if s.expired(entry) {
+			s.remove(key)
+		}
+	}
+}
+
+func (s *TokenStore[T]) expired(entry tokenEntry[T]) bool {
+	return entry.ttl > 0 && !s.now().Before(entry.expiresAt)
+}
+
+// estimateSize returns the size of the JSON encoding of the value,
+// or the size of its type if it can't be encoded.
+func estimateSize[T any](value T) uint64 {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return uint64(reflect.TypeOf(&value).Elem().Size())
+	}
+
+	return uint64(len(data))
+}
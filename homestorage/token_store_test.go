@@ -0,0 +1,219 @@
+package homestorage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNow struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+func (f *fakeNow) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.now
+}
+
+func (f *fakeNow) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.now = f.now.Add(d)
+}
+
+func TestTokenStore_Expiration(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeNow{now: time.Now()}
+	s := NewTokenStore[string](WithTTL(time.Minute))
+	s.now = clock.Now
+
+	require.NoError(t, s.Set("key", "token"))
+	require.NoError(t, s.SetWithTTL("forever", "token", 0))
+
+	got, err := s.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "token", got)
+
+	clock.Advance(time.Minute)
+
+	_, err = s.Get("key")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.Get("forever")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), s.Count())
+}
+
+func TestTokenStore_SlidingExpiration(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeNow{now: time.Now()}
+	s := NewTokenStore[string](WithTTL(time.Minute), WithSlidingExpiration())
+	s.now = clock.Now
+
+	require.NoError(t, s.Set("key", "token"))
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(50 * time.Second)
+
+		_, err := s.Get("key")
+		require.NoError(t, err)
+	}
+
+	clock.Advance(time.Minute)
+
+	_, err := s.Get("key")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTokenStore_Capacity(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeNow{now: time.Now()}
+	s := NewTokenStore[int](WithCapacity(1), WithTTL(time.Second))
+	s.now = clock.Now
+
+	require.NoError(t, s.Set("key", 1))
+	require.NoError(t, s.Set("key", 2))
+	require.ErrorIs(t, s.Set("key2", 3), ErrCapacityExceeded)
+
+	// expired entries are purged to free the space
+	clock.Advance(time.Second)
+	require.NoError(t, s.Set("key2", 3))
+}
+
+func TestTokenStore_GetOrRefresh(t *testing.T) {
+	t.Parallel()
+
+	s := NewTokenStore[string](WithTTL(time.Minute))
+
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+
+	fetch := func(_ context.Context, key string) (string, error) {
+		calls.Add(1)
+		<-release
+
+		return "token-" + key, nil
+	}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() { //nolint:wsl
+			defer wg.Done()
+
+			got, err := s.GetOrRefresh(context.Background(), "key", fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, "token-key", got)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	// the value is cached now
+	got, err := s.GetOrRefresh(context.Background(), "key", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "token-key", got)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestTokenStore_GetOrRefreshError(t *testing.T) {
+	t.Parallel()
+
+	s := NewTokenStore[string]()
+	errFetch := errors.New("fetch failed")
+
+	_, err := s.GetOrRefresh(context.Background(), "key", func(context.Context, string) (string, error) {
+		return "", errFetch
+	})
+	require.ErrorIs(t, err, errFetch)
+
+	_, err = s.Get("key")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTokenStore_GetOrRefreshContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	s := NewTokenStore[string]()
+	release := make(chan struct{})
+
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := s.GetOrRefresh(ctx, "key", func(context.Context, string) (string, error) {
+		<-release
+
+		return "token", nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTokenStore_MaxBytes(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeNow{now: time.Now()}
+	s := NewTokenStore[string](WithMaxBytes(10), WithSizeFunc(func(v string) uint64 { return uint64(len(v)) }))
+	s.now = clock.Now
+
+	require.NoError(t, s.Set("a", "aaaa"))
+	clock.Advance(time.Second)
+	require.NoError(t, s.Set("b", "bbbb"))
+	clock.Advance(time.Second)
+	assert.Equal(t, uint64(8), s.Bytes())
+
+	// the oldest value is evicted to make room
+	require.NoError(t, s.Set("c", "cccc"))
+	assert.Equal(t, uint64(8), s.Bytes())
+
+	_, err := s.Get("a")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// replacing a value accounts for its previous size
+	require.NoError(t, s.Set("c", "cccccc"))
+	assert.Equal(t, uint64(10), s.Bytes())
+	assert.Equal(t, uint64(2), s.Count())
+
+	require.ErrorIs(t, s.Set("d", "too large value"), ErrCapacityExceeded)
+
+	s.Delete("b")
+	assert.Equal(t, uint64(6), s.Bytes())
+}
+
+func TestTokenStore_MaxBytesDefaultEstimate(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Data string `json:"data"`
+	}
+
+	s := NewTokenStore[payload](WithMaxBytes(64))
+
+	require.NoError(t, s.Set("key", payload{Data: "value"}))
+	assert.Equal(t, uint64(len(`{"data":"value"}`)), s.Bytes())
+
+	// a size func of another type is ignored
+	s = NewTokenStore[payload](WithMaxBytes(64), WithSizeFunc(func(string) uint64 { return 100 }))
+	require.NoError(t, s.Set("key", payload{Data: "value"}))
+	assert.Equal(t, uint64(len(`{"data":"value"}`)), s.Bytes())
+}
@@ -0,0 +1,297 @@
+package hometests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const redactedValue = "REDACTED"
+
+// CassetteMode defines whether CassetteRecorder talks to the real service or replays recorded interactions.
+type CassetteMode int
+
+const (
+	// CassetteAuto replays the cassette if it exists and records a new one otherwise.
+	CassetteAuto CassetteMode = iota
+	// CassetteReplay only replays recorded interactions, unknown requests fail.
+	CassetteReplay
+	// CassetteRecord always sends requests to the real service and overwrites the cassette.
+	CassetteRecord
+)
+
+// Cassette is a set of recorded HTTP interactions stored on disk as JSON.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request.
+type CassetteRequest struct {
+	Headers http.Header `json:"headers"`
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Body    string      `json:"body"`
+}
+
+// CassetteResponse is a recorded response.
+type CassetteResponse struct {
+	Headers http.Header `json:"headers"`
+	Body    string      `json:"body"`
+	Status  int         `json:"status"`
+}
+
+// CassetteMatcher reports whether the recorded request matches the actual one.
+type CassetteMatcher func(req *http.Request, body []byte, recorded CassetteRequest) bool
+
+// CassetteOption configures CassetteRecorder.
+type CassetteOption interface {
+	apply(r *CassetteRecorder)
+}
+
+type cassetteOptionFn func(r *CassetteRecorder)
+
+func (fn cassetteOptionFn) apply(r *CassetteRecorder) {
+	fn(r)
+}
+
+// WithCassetteMode sets the recorder mode. The default mode is CassetteAuto.
+func WithCassetteMode(mode CassetteMode) CassetteOption {
+	return cassetteOptionFn(func(r *CassetteRecorder) {
+		r.mode = mode
+	})
+}
+
+// WithRealTransport sets the transport used to reach the real service. The default is http.DefaultTransport.
+func WithRealTransport(rt http.RoundTripper) CassetteOption {
+	return cassetteOptionFn(func(r *CassetteRecorder) {
+		r.real = rt
+	})
+}
+
+// WithRedactedHeaders adds headers whose values are masked before the cassette is written.
+// Authorization, Cookie, Set-Cookie and X-Api-Key are always redacted.
+func WithRedactedHeaders(names ...string) CassetteOption {
+	return cassetteOptionFn(func(r *CassetteRecorder) {
+		r.redacted = append(r.redacted, names...)
+	})
+}
+
+// WithCassetteMatcher replaces the default matcher that compares method and URL.
+func WithCassetteMatcher(m CassetteMatcher) CassetteOption {
+	return cassetteOptionFn(func(r *CassetteRecorder) {
+		r.matcher = m
+	})
+}
+
+// CassetteRecorder is a VCR-style http.RoundTripper.
+// In record mode it proxies requests to the real service and writes sanitized interactions to the cassette
+// when the test finishes, in replay mode it serves the recorded responses without touching the network.
+type CassetteRecorder struct {
+	real     http.RoundTripper
+	matcher  CassetteMatcher
+	path     string
+	redacted []string
+	cassette Cassette
+	used     []bool
+	mode     CassetteMode
+
+	mutex sync.Mutex
+}
+
+var _ http.RoundTripper = (*CassetteRecorder)(nil)
+
+// NewCassetteRecorder returns a recorder backed by the cassette file at path.
+// The mode can be forced to CassetteRecord by setting HOMETESTS_RECORD=1.
+func NewCassetteRecorder(t *testing.T, path string, opts ...CassetteOption) *CassetteRecorder {
+	t.Helper()
+
+	r := &CassetteRecorder{
+		real:     http.DefaultTransport,
+		matcher:  matchMethodAndURL,
+		path:     path,
+		redacted: []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+	}
+
+	for _, opt := range opts {
+		opt.apply(r)
+	}
+
+	if os.Getenv("HOMETESTS_RECORD") == "1" {
+		r.mode = CassetteRecord
+	}
+
+	content, err := os.ReadFile(path)
+
+	switch {
+	case err == nil && r.mode != CassetteRecord:
+		if err = json.Unmarshal(content, &r.cassette); err != nil {
+			t.Fatalf("failed to decode cassette %s: %v", path, err)
+		}
+
+		r.mode = CassetteReplay
+		r.used = make([]bool, len(r.cassette.Interactions))
+	case errors.Is(err, os.ErrNotExist) && r.mode == CassetteAuto:
+		r.mode = CassetteRecord
+	case err != nil && r.mode == CassetteReplay:
+		t.Fatalf("failed to read cassette %s: %v", path, err)
+	}
+
+	if r.mode == CassetteRecord {
+		t.Cleanup(func() {
+			if err := r.save(); err != nil {
+				t.Errorf("failed to save cassette %s: %v", path, err)
+			}
+		})
+	}
+
+	return r
+}
+
+// Recording reports whether the recorder talks to the real service.
+func (r *CassetteRecorder) Recording() bool {
+	return r.mode == CassetteRecord
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *CassetteRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == CassetteRecord {
+		return r.record(req, body)
+	}
+
+	return r.replay(req, body)
+}
+
+func (r *CassetteRecorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.real.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: CassetteRequest{
+			Headers: r.redact(req.Header),
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Body:    string(body),
+		},
+		Response: CassetteResponse{
+			Headers: r.redact(resp.Header),
+			Body:    string(respBody),
+			Status:  resp.StatusCode,
+		},
+	})
+
+	return resp, nil
+}
+
+func (r *CassetteRecorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// prefer interactions that haven't been replayed yet, so repeated requests get responses in the recorded order
+	match := -1
+
+	for i, interaction := range r.cassette.Interactions {
+		if !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+
+		match = i
+
+		if !r.used[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded interaction for %s %s in %s", req.Method, req.URL, r.path)
+	}
+
+	r.used[match] = true
+	recorded := r.cassette.Interactions[match].Response
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *CassetteRecorder) save() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, content, 0o600)
+}
+
+func (r *CassetteRecorder) redact(h http.Header) http.Header {
+	h = h.Clone()
+
+	for _, name := range r.redacted {
+		if values := h.Values(name); len(values) > 0 {
+			h.Set(name, redactedValue)
+		}
+	}
+
+	return h
+}
+
+func matchMethodAndURL(req *http.Request, _ []byte, recorded CassetteRequest) bool {
+	return req.Method == recorded.Method && req.URL.String() == recorded.URL
+}
@@ -0,0 +1,80 @@
+package hometests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassetteRecorder_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	server, capture := CaptureServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write([]byte("hello " + r.URL.Path))
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassettes", "example.json")
+
+	t.Run("record", func(t *testing.T) {
+		recorder := NewCassetteRecorder(t, path)
+		require.True(t, recorder.Recording())
+
+		body := doCassetteRequest(t, recorder, server.URL+"/a", "token")
+		assert.Equal(t, "hello /a", body)
+	})
+
+	capture.AssertCalled(t, 1)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var cassette Cassette
+	require.NoError(t, json.Unmarshal(content, &cassette))
+	require.Len(t, cassette.Interactions, 1)
+	assert.Equal(t, redactedValue, cassette.Interactions[0].Request.Headers.Get("Authorization"))
+	assert.Equal(t, redactedValue, cassette.Interactions[0].Response.Headers.Get("Set-Cookie"))
+	assert.NotContains(t, string(content), "secret")
+
+	t.Run("replay", func(t *testing.T) {
+		recorder := NewCassetteRecorder(t, path)
+		require.False(t, recorder.Recording())
+
+		body := doCassetteRequest(t, recorder, server.URL+"/a", "token")
+		assert.Equal(t, "hello /a", body)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/unknown", http.NoBody)
+		require.NoError(t, err)
+
+		_, err = recorder.RoundTrip(req)
+		assert.Error(t, err)
+	})
+
+	capture.AssertCalled(t, 1)
+}
+
+func doCassetteRequest(t *testing.T, rt http.RoundTripper, url, token string) string {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader("payload"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
@@ -0,0 +1,259 @@
+package hometests
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const defaultChaosSeed = 42
+
+// ChaosOption configures the misbehavior of ChaosServer.
+type ChaosOption interface {
+	apply(cfg *chaosConfig)
+}
+
+type chaosOptionFn func(cfg *chaosConfig)
+
+func (fn chaosOptionFn) apply(cfg *chaosConfig) {
+	fn(cfg)
+}
+
+type chaosConfig struct {
+	seed int64
+
+	failureRate   float64
+	failureStatus int
+
+	delayRate float64
+	minDelay  time.Duration
+	maxDelay  time.Duration
+
+	dropRate     float64
+	truncateRate float64
+
+	slowChunkSize  int
+	slowChunkDelay time.Duration
+}
+
+// WithSeed sets the seed of the random source, so the same seed reproduces the same sequence of failures.
+func WithSeed(seed int64) ChaosOption {
+	return chaosOptionFn(func(cfg *chaosConfig) {
+		cfg.seed = seed
+	})
+}
+
+// WithFailures makes the server respond with the given status to the given fraction of requests.
+func WithFailures(rate float64, status int) ChaosOption {
+	return chaosOptionFn(func(cfg *chaosConfig) {
+		cfg.failureRate = rate
+		cfg.failureStatus = status
+	})
+}
+
+// WithRandomDelays delays the given fraction of requests by a random duration in [min, max).
+func WithRandomDelays(rate float64, minDelay, maxDelay time.Duration) ChaosOption {
+	return chaosOptionFn(func(cfg *chaosConfig) {
+		cfg.delayRate = rate
+		cfg.minDelay = minDelay
+		cfg.maxDelay = maxDelay
+	})
+}
+
+// WithDroppedConnections closes the connection without any response for the given fraction of requests.
+func WithDroppedConnections(rate float64) ChaosOption {
+	return chaosOptionFn(func(cfg *chaosConfig) {
+		cfg.dropRate = rate
+	})
+}
+
+// WithTruncatedBodies sends only half of the announced response body
+// and closes the connection for the given fraction of requests.
+func WithTruncatedBodies(rate float64) ChaosOption {
+	return chaosOptionFn(func(cfg *chaosConfig) {
+		cfg.truncateRate = rate
+	})
+}
+
+// WithSlowWrites writes response bodies in chunks of chunkSize bytes, waiting delay between the chunks.
+func WithSlowWrites(chunkSize int, delay time.Duration) ChaosOption {
+	return chaosOptionFn(func(cfg *chaosConfig) {
+		cfg.slowChunkSize = chunkSize
+		cfg.slowChunkDelay = delay
+	})
+}
+
+// FlakyServer starts a test server that responds with failureStatus to the failureRate fraction of requests
+// and with 200 OK to the rest. The failures are deterministic for the given seed (42 by default).
+func FlakyServer(t *testing.T, failureRate float64, failureStatus int, opts ...ChaosOption) *httptest.Server {
+	t.Helper()
+
+	return ChaosServer(t, nil, append([]ChaosOption{WithFailures(failureRate, failureStatus)}, opts...)...)
+}
+
+// ChaosServer starts a test server that wraps the handler with the configured misbehavior.
+// If the handler is nil, the server responds with 200 OK.
+// The server is closed automatically when the test finishes.
+func ChaosServer(t *testing.T, handler http.Handler, opts ...ChaosOption) *httptest.Server {
+	t.Helper()
+
+	cfg := &chaosConfig{seed: defaultChaosSeed}
+
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	if handler == nil {
+		handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("OK"))
+		})
+	}
+
+	c := &chaosHandler{
+		cfg:  cfg,
+		next: handler,
+		rand: rand.New(rand.NewSource(cfg.seed)), //nolint:gosec
+	}
+
+	server := httptest.NewServer(c)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+type chaosHandler struct {
+	cfg  *chaosConfig
+	next http.Handler
+	rand *rand.Rand
+
+	mutex sync.Mutex
+}
+
+type chaosDecision struct {
+	delay    time.Duration
+	drop     bool
+	fail     bool
+	truncate bool
+}
+
+// decide draws all random values for a request at once,
+// so the sequence of decisions depends only on the seed and the order of requests.
+func (c *chaosHandler) decide() chaosDecision {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var d chaosDecision
+
+	if c.rand.Float64() < c.cfg.delayRate {
+		d.delay = c.cfg.minDelay
+		if spread := c.cfg.maxDelay - c.cfg.minDelay; spread > 0 {
+			d.delay += time.Duration(c.rand.Int63n(int64(spread)))
+		}
+	}
+
+	d.drop = c.rand.Float64() < c.cfg.dropRate
+	d.fail = c.rand.Float64() < c.cfg.failureRate
+	d.truncate = c.rand.Float64() < c.cfg.truncateRate
+
+	return d
+}
+
+func (c *chaosHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	d := c.decide()
+
+	if d.delay > 0 {
+		select {
+		case <-time.After(d.delay):
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	if d.drop {
+		closeConnection(w)
+		return
+	}
+
+	if d.fail {
+		w.WriteHeader(c.cfg.failureStatus)
+		return
+	}
+
+	if !d.truncate && c.cfg.slowChunkSize <= 0 {
+		c.next.ServeHTTP(w, req)
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	c.next.ServeHTTP(rec, req)
+
+	body := rec.Body.Bytes()
+
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(rec.Code)
+
+	if d.truncate {
+		body = body[:len(body)/2]
+	}
+
+	c.writeBody(w, req, body)
+
+	if d.truncate {
+		closeConnection(w)
+	}
+}
+
+func (c *chaosHandler) writeBody(w http.ResponseWriter, req *http.Request, body []byte) {
+	chunkSize := c.cfg.slowChunkSize
+	if chunkSize <= 0 {
+		chunkSize = len(body)
+	}
+
+	flusher, _ := w.(http.Flusher)
+
+	for len(body) > 0 {
+		n := min(chunkSize, len(body))
+
+		if _, err := w.Write(body[:n]); err != nil {
+			return
+		}
+
+		body = body[n:]
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		if len(body) > 0 && c.cfg.slowChunkDelay > 0 {
+			select {
+			case <-time.After(c.cfg.slowChunkDelay):
+			case <-req.Context().Done():
+				return
+			}
+		}
+	}
+}
+
+// closeConnection closes the underlying connection, anything written so far is flushed.
+func closeConnection(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return
+	}
+
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+
+	_ = conn.Close()
+}
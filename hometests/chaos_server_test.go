@@ -0,0 +1,80 @@
+package hometests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlakyServer_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	statuses := func(seed int64) []int {
+		s := FlakyServer(t, 0.5, http.StatusServiceUnavailable, WithSeed(seed))
+
+		var got []int
+
+		for i := 0; i < 20; i++ {
+			got = append(got, doRequest(t, http.MethodGet, s.URL).StatusCode)
+		}
+
+		return got
+	}
+
+	first, second := statuses(7), statuses(7)
+
+	assert.Equal(t, first, second)
+	assert.Contains(t, first, http.StatusServiceUnavailable)
+	assert.Contains(t, first, http.StatusOK)
+}
+
+func TestChaosServer_DroppedConnections(t *testing.T) {
+	t.Parallel()
+
+	s := ChaosServer(t, nil, WithDroppedConnections(1))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, s.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+
+	assert.Error(t, err)
+}
+
+func TestChaosServer_TruncatedBodies(t *testing.T) {
+	t.Parallel()
+
+	s := ChaosServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("0123456789"))
+	}), WithTruncatedBodies(1))
+
+	resp := doRequest(t, http.MethodGet, s.URL)
+	body, err := io.ReadAll(resp.Body)
+
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, "01234", string(body))
+}
+
+func TestChaosServer_SlowWritesAndDelays(t *testing.T) {
+	t.Parallel()
+
+	s := ChaosServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("abcd"))
+	}), WithSlowWrites(1, 10*time.Millisecond), WithRandomDelays(1, 20*time.Millisecond, 20*time.Millisecond))
+
+	start := time.Now()
+	resp := doRequest(t, http.MethodGet, s.URL)
+	body, err := io.ReadAll(resp.Body)
+
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", string(body))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
@@ -0,0 +1,253 @@
+package hometests
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts the time functions, so the code can be tested with FakeClock.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the Clock counterpart of time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the Clock counterpart of time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// RealClock returns a Clock backed by the time package.
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// FakeClock is a Clock that moves only when Advance is called.
+// Timers, tickers and sleepers fire synchronously during Advance.
+type FakeClock struct {
+	now     time.Time
+	waiters []*fakeWaiter
+
+	mutex   sync.Mutex
+	changed *sync.Cond
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+	period   time.Duration
+}
+
+// NewFakeClock returns a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.changed = sync.NewCond(&c.mutex)
+
+	return c
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// Since returns the fake time elapsed since t.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After returns a channel that receives the fake time once it is advanced by d,
+// or right away if d isn't positive.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Sleep blocks until the clock is advanced by d, it returns right away if d isn't positive.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// NewTimer returns a Timer that fires once the clock is advanced by d, like time.NewTimer
+// it fires right away if d isn't positive.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{ch: make(chan time.Time, 1)}
+	c.schedule(w, d)
+
+	return &fakeTimer{clock: c, waiter: w}
+}
+
+// NewTicker returns a Ticker that fires every time the clock is advanced by d.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	w := &fakeWaiter{ch: make(chan time.Time, 1), period: d}
+	c.schedule(w, d)
+
+	return &fakeTicker{clock: c, waiter: w}
+}
+
+// Advance moves the clock forward by d and fires all timers and tickers that are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	end := c.now.Add(d)
+
+	for {
+		sort.Slice(c.waiters, func(i, j int) bool {
+			return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+		})
+
+		if len(c.waiters) == 0 || c.waiters[0].deadline.After(end) {
+			break
+		}
+
+		w := c.waiters[0]
+		c.now = w.deadline
+
+		// like the real tickers, drop the tick if the previous one hasn't been consumed
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+		} else {
+			c.waiters = c.waiters[1:]
+		}
+	}
+
+	c.now = end
+	c.changed.Broadcast()
+}
+
+// BlockUntil blocks until at least n timers, tickers or sleepers are waiting on the clock.
+// It is used to make sure that the code under test reached its waiting point before calling Advance.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for len(c.waiters) < n {
+		c.changed.Wait()
+	}
+}
+
+// Waiters returns the number of timers, tickers and sleepers waiting on the clock.
+func (c *FakeClock) Waiters() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.waiters)
+}
+
+func (c *FakeClock) schedule(w *fakeWaiter, d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	w.deadline = c.now.Add(d)
+
+	// an expired timer fires without waiting for Advance, nothing would advance the clock for it otherwise
+	if d <= 0 && w.period == 0 {
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+
+		return
+	}
+
+	c.waiters = append(c.waiters, w)
+	c.changed.Broadcast()
+}
+
+// remove unschedules the waiter, returns false if it wasn't scheduled.
+func (c *FakeClock) remove(w *fakeWaiter) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, existing := range c.waiters {
+		if existing == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.changed.Broadcast()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	return t.clock.remove(t.waiter)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	active := t.clock.remove(t.waiter)
+	t.clock.schedule(t.waiter, d)
+
+	return active
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.remove(t.waiter)
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.clock.remove(t.waiter)
+
+	t.clock.mutex.Lock()
+	t.waiter.period = d
+	t.clock.mutex.Unlock()
+
+	t.clock.schedule(t.waiter, d)
+}
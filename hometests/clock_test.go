@@ -0,0 +1,131 @@
+package hometests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Clock = (*FakeClock)(nil)
+
+func TestFakeClock_Timer(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	timer := c.NewTimer(time.Second)
+	stopped := c.NewTimer(time.Second)
+	require.True(t, stopped.Stop())
+
+	c.Advance(500 * time.Millisecond)
+
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired too early")
+	default:
+	}
+
+	c.Advance(500 * time.Millisecond)
+
+	select {
+	case got := <-timer.C():
+		assert.Equal(t, start.Add(time.Second), got)
+	default:
+		t.Fatal("timer didn't fire")
+	}
+
+	assert.Empty(t, stopped.C())
+	assert.False(t, timer.Stop())
+	assert.Equal(t, start.Add(time.Second), c.Now())
+	assert.Equal(t, time.Second, c.Since(start))
+}
+
+func TestFakeClock_NonPositiveDurations(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	for _, d := range []time.Duration{0, -time.Second} {
+		select {
+		case got := <-c.NewTimer(d).C():
+			assert.Equal(t, start, got)
+		default:
+			t.Fatalf("timer of %s didn't fire", d)
+		}
+
+		select {
+		case <-c.After(d):
+		default:
+			t.Fatalf("After(%s) didn't fire", d)
+		}
+
+		c.Sleep(d)
+	}
+
+	timer := c.NewTimer(time.Second)
+	assert.True(t, timer.Reset(0))
+	assert.Equal(t, start, <-timer.C())
+
+	assert.Equal(t, 0, c.Waiters())
+	assert.Equal(t, start, c.Now())
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	t.Parallel()
+
+	c := NewFakeClock(time.Now())
+	ticker := c.NewTicker(time.Second)
+
+	var ticks int
+
+	for i := 0; i < 3; i++ {
+		c.Advance(time.Second)
+
+		<-ticker.C()
+		ticks++
+	}
+
+	ticker.Stop()
+	c.Advance(time.Second)
+
+	assert.Equal(t, 3, ticks)
+	assert.Empty(t, ticker.C())
+	assert.Equal(t, 0, c.Waiters())
+}
+
+func TestFakeClock_SleepAndBlockUntil(t *testing.T) {
+	t.Parallel()
+
+	c := NewFakeClock(time.Now())
+	done := make(chan struct{})
+
+	go func() {
+		c.Sleep(time.Minute)
+		close(done)
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sleeper wasn't woken up")
+	}
+}
+
+func TestRealClock(t *testing.T) {
+	t.Parallel()
+
+	c := RealClock()
+	start := c.Now()
+
+	timer := c.NewTimer(time.Millisecond)
+	<-timer.C()
+
+	assert.Positive(t, c.Since(start))
+}
@@ -0,0 +1,74 @@
+package hometests
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// deadlineGrace is subtracted from the test deadline, so the test can report a failure before the test binary panics.
+const deadlineGrace = time.Second
+
+// ContextFromTest returns a context that is canceled when the test finishes
+// or shortly before the test binary deadline (set with -timeout) is reached.
+func ContextFromTest(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx := context.Background()
+	cancel := func() {}
+
+	if deadline, ok := t.Deadline(); ok {
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-deadlineGrace))
+	}
+
+	ctx, cancelOnCleanup := context.WithCancel(ctx)
+
+	t.Cleanup(func() {
+		cancelOnCleanup()
+		cancel()
+	})
+
+	return ctx
+}
+
+// ContextCancelledAfter returns a test context that is additionally canceled after d.
+func ContextCancelledAfter(t *testing.T, d time.Duration) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(ContextFromTest(t), d)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+// MustComplete runs fn with a test context and fails the test if fn doesn't return before the context is done.
+func MustComplete(t *testing.T, fn func(ctx context.Context)) {
+	t.Helper()
+
+	mustComplete(ContextFromTest(t), t, fn)
+}
+
+// MustCompleteWithin runs fn and fails the test if fn doesn't return within the timeout.
+func MustCompleteWithin(t *testing.T, timeout time.Duration, fn func(ctx context.Context)) {
+	t.Helper()
+
+	mustComplete(ContextCancelledAfter(t, timeout), t, fn)
+}
+
+func mustComplete(ctx context.Context, t *testing.T, fn func(ctx context.Context)) {
+	t.Helper()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		fn(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatalf("function didn't complete: %v", ctx.Err())
+	}
+}
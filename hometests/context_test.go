@@ -0,0 +1,54 @@
+package hometests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextFromTest(t *testing.T) {
+	t.Parallel()
+
+	var ctx context.Context
+
+	t.Run("canceled on cleanup", func(t *testing.T) {
+		ctx = ContextFromTest(t)
+		require.NoError(t, ctx.Err())
+
+		if testDeadline, ok := t.Deadline(); ok {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.True(t, deadline.Before(testDeadline))
+		}
+	})
+
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestContextCancelledAfter(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextCancelledAfter(t, 10*time.Millisecond)
+
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestMustComplete(t *testing.T) {
+	t.Parallel()
+
+	var called bool
+
+	MustComplete(t, func(ctx context.Context) {
+		called = ctx.Err() == nil
+	})
+
+	MustCompleteWithin(t, time.Second, func(context.Context) {
+		time.Sleep(time.Millisecond)
+	})
+
+	assert.True(t, called)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/joho/godotenv"
@@ -53,6 +54,44 @@ func EnvOverrideMany(t *testing.T, envs map[string]string) func() {
 	}
 }
 
+// EnvSnapshot captures the whole process environment and restores it when the test finishes.
+// Variables added during the test are removed, changed and removed ones are restored.
+// It changes the process environment, so it must not be used in parallel tests.
+func EnvSnapshot(t *testing.T) {
+	t.Helper()
+
+	snapshot := os.Environ()
+
+	t.Cleanup(func() {
+		os.Clearenv()
+
+		for _, kv := range snapshot {
+			key, value, _ := strings.Cut(kv, "=")
+			_ = os.Setenv(key, value)
+		}
+	})
+}
+
+// EnvClear removes all environment variables except the given ones for the duration of the test.
+// The original environment is restored when the test finishes.
+func EnvClear(t *testing.T, except ...string) {
+	t.Helper()
+
+	EnvSnapshot(t)
+
+	keep := make(map[string]bool, len(except))
+	for _, key := range except {
+		keep[key] = true
+	}
+
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		if !keep[key] {
+			_ = os.Unsetenv(key)
+		}
+	}
+}
+
 // LoadEnvFromTheFile loads env variables from the file.
 // File will be searched in the current directory and up the tree.
 // If override is true, it overrides the existing env variables.
@@ -0,0 +1,88 @@
+package hometests
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+// ScopedEnv is an isolated set of environment variables of a single test, see EnvScope.
+// Its Getenv and LookupEnv methods match the os functions, so code reading the environment through
+// an injected accessor can be tested in parallel, unlike with t.Setenv.
+type ScopedEnv struct {
+	values map[string]string
+
+	mutex sync.RWMutex
+}
+
+// EnvScope returns a new empty ScopedEnv, it never touches the process environment.
+//
+//	env := hometests.EnvScope(t).Set("APP_TIMEOUT", "1s")
+//	cfg, err := homeconfig.LoadFrom[Config]("APP", env.LookupEnv)
+func EnvScope(t *testing.T) *ScopedEnv {
+	t.Helper()
+
+	return &ScopedEnv{values: make(map[string]string)}
+}
+
+// Set sets the variable and returns the scope for chaining.
+func (e *ScopedEnv) Set(key, value string) *ScopedEnv {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.values[key] = value
+
+	return e
+}
+
+// SetMany sets all given variables and returns the scope for chaining.
+func (e *ScopedEnv) SetMany(envs map[string]string) *ScopedEnv {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for key, value := range envs {
+		e.values[key] = value
+	}
+
+	return e
+}
+
+// Unset removes the variable.
+func (e *ScopedEnv) Unset(key string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	delete(e.values, key)
+}
+
+// Getenv returns the value of the variable, or an empty string if it is not set.
+func (e *ScopedEnv) Getenv(key string) string {
+	value, _ := e.LookupEnv(key)
+
+	return value
+}
+
+// LookupEnv returns the value of the variable and whether it is set.
+func (e *ScopedEnv) LookupEnv(key string) (string, bool) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	value, ok := e.values[key]
+
+	return value, ok
+}
+
+// Environ returns the variables in the "key=value" form sorted by key, like os.Environ.
+func (e *ScopedEnv) Environ() []string {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	environ := make([]string, 0, len(e.values))
+	for key, value := range e.values {
+		environ = append(environ, key+"="+value)
+	}
+
+	sort.Strings(environ)
+
+	return environ
+}
@@ -0,0 +1,64 @@
+package hometests
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vmyroslav/home-lib/homeconfig"
+)
+
+func TestEnvScope(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Name    string        `env:"NAME,required"`
+		Timeout time.Duration `env:"TIMEOUT,default=5s"`
+	}
+
+	for _, name := range []string{"first", "second", "third"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			env := EnvScope(t).Set("APP_NAME", name)
+
+			cfg, err := homeconfig.LoadFrom[config]("APP", env.LookupEnv)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cfg.Name != name || cfg.Timeout != 5*time.Second {
+				t.Errorf("unexpected config %+v", cfg)
+			}
+
+			if _, ok := os.LookupEnv("APP_NAME"); ok {
+				t.Error("the process environment must not be changed")
+			}
+		})
+	}
+}
+
+func TestScopedEnv(t *testing.T) {
+	t.Parallel()
+
+	env := EnvScope(t).SetMany(map[string]string{"B": "2", "A": "1", "EMPTY": ""})
+
+	if got := env.Getenv("A"); got != "1" {
+		t.Errorf("Expected A=1, but got %q", got)
+	}
+
+	if _, ok := env.LookupEnv("EMPTY"); !ok {
+		t.Error("Expected EMPTY to be set")
+	}
+
+	env.Unset("EMPTY")
+
+	if _, ok := env.LookupEnv("EMPTY"); ok {
+		t.Error("Expected EMPTY to be unset")
+	}
+
+	if got, want := env.Environ(), []string{"A=1", "B=2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+}
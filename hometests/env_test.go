@@ -93,3 +93,53 @@ func TestLoadEnvFromTheFile(t *testing.T) {
 		os.Remove(fileName)
 	})
 }
+
+func TestEnvSnapshot(t *testing.T) { //nolint:paralleltest
+	key := t.Name() + "_KEY"
+	added := t.Name() + "_ADDED"
+
+	_ = os.Setenv(key, "original") //nolint:tenv
+	defer os.Unsetenv(key)
+
+	t.Run("modify environment", func(t *testing.T) {
+		EnvSnapshot(t)
+
+		_ = os.Setenv(key, "changed") //nolint:tenv
+		_ = os.Setenv(added, "value") //nolint:tenv
+	})
+
+	if got := os.Getenv(key); got != "original" {
+		t.Errorf("Expected environment variable %s to be restored to original, but got %s", key, got)
+	}
+
+	if _, ok := os.LookupEnv(added); ok {
+		t.Errorf("Expected environment variable %s to be removed", added)
+	}
+}
+
+func TestEnvClear(t *testing.T) { //nolint:paralleltest
+	key := t.Name() + "_KEY"
+	kept := t.Name() + "_KEPT"
+
+	_ = os.Setenv(key, "value")  //nolint:tenv
+	_ = os.Setenv(kept, "value") //nolint:tenv
+
+	defer os.Unsetenv(key)
+	defer os.Unsetenv(kept)
+
+	t.Run("clear environment", func(t *testing.T) {
+		EnvClear(t, kept)
+
+		if _, ok := os.LookupEnv(key); ok {
+			t.Errorf("Expected environment variable %s to be cleared", key)
+		}
+
+		if os.Getenv(kept) != "value" {
+			t.Errorf("Expected environment variable %s to be kept", kept)
+		}
+	})
+
+	if os.Getenv(key) != "value" {
+		t.Errorf("Expected environment variable %s to be restored", key)
+	}
+}
@@ -0,0 +1,87 @@
+package hometests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Eventually polls cond every interval and fails the test if it doesn't return true within the timeout.
+// msgAndArgs are an optional format string with arguments added to the failure message.
+func Eventually(t *testing.T, cond func() bool, timeout, interval time.Duration, msgAndArgs ...any) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	EventuallyContext(ctx, t, func(context.Context) bool { return cond() }, interval, msgAndArgs...)
+}
+
+// EventuallyContext polls cond every interval and fails the test if it doesn't return true before ctx is done.
+func EventuallyContext(ctx context.Context, t *testing.T, cond func(ctx context.Context) bool, interval time.Duration, msgAndArgs ...any) {
+	t.Helper()
+
+	start := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for attempts := 1; ; attempts++ {
+		if cond(ctx) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("condition not satisfied after %s (%d attempts): %v%s",
+				time.Since(start).Round(time.Millisecond), attempts, ctx.Err(), formatMessage(msgAndArgs))
+		case <-ticker.C:
+		}
+	}
+}
+
+// Consistently polls cond every interval and fails the test if it returns false at any time during the duration.
+func Consistently(t *testing.T, cond func() bool, duration, interval time.Duration, msgAndArgs ...any) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	ConsistentlyContext(ctx, t, func(context.Context) bool { return cond() }, interval, msgAndArgs...)
+}
+
+// ConsistentlyContext polls cond every interval and fails the test if it returns false at any time before ctx is done.
+func ConsistentlyContext(ctx context.Context, t *testing.T, cond func(ctx context.Context) bool, interval time.Duration, msgAndArgs ...any) {
+	t.Helper()
+
+	start := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for attempts := 1; ; attempts++ {
+		if !cond(ctx) {
+			t.Fatalf("condition became false after %s (attempt %d)%s",
+				time.Since(start).Round(time.Millisecond), attempts, formatMessage(msgAndArgs))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func formatMessage(msgAndArgs []any) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+
+	if format, ok := msgAndArgs[0].(string); ok {
+		return ": " + fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+
+	return ": " + fmt.Sprint(msgAndArgs...)
+}
@@ -0,0 +1,46 @@
+package hometests
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventually(t *testing.T) {
+	t.Parallel()
+
+	var counter atomic.Int32
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			time.Sleep(5 * time.Millisecond)
+			counter.Add(1)
+		}
+	}()
+
+	Eventually(t, func() bool { return counter.Load() == 5 }, time.Second, time.Millisecond)
+}
+
+func TestConsistently(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	Consistently(t, func() bool {
+		calls++
+
+		return true
+	}, 30*time.Millisecond, 5*time.Millisecond)
+
+	assert.Greater(t, calls, 1)
+}
+
+func TestFormatMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", formatMessage(nil))
+	assert.Equal(t, ": value is 42", formatMessage([]any{"value is %d", 42}))
+	assert.Equal(t, ": 42", formatMessage([]any{42}))
+}
@@ -0,0 +1,113 @@
+package hometests
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TempFile creates a file with the given name and content in a temporary directory.
+// Returns the path of the file, the directory is removed when the test finishes.
+func TempFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	writeFile(t, path, []byte(content))
+
+	return path
+}
+
+// TempJSONFile creates a temporary file with v encoded as JSON.
+// Returns the path of the file, the file is removed when the test finishes.
+func TempJSONFile(t *testing.T, v any) string {
+	t.Helper()
+
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	writeFile(t, path, content)
+
+	return path
+}
+
+// TempDirWithFiles creates a temporary directory with the given files.
+// The keys are paths relative to the directory, the values are file contents.
+// Returns the path of the directory, it is removed when the test finishes.
+func TempDirWithFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		writeFile(t, filepath.Join(dir, filepath.FromSlash(name)), []byte(content))
+	}
+
+	return dir
+}
+
+// CopyFixture copies a file or a directory (e.g. from testdata) into a temporary directory,
+// so the test can modify it freely. Returns the path of the copy.
+func CopyFixture(t *testing.T, src string) string {
+	t.Helper()
+
+	info, err := os.Stat(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), filepath.Base(src))
+
+	if !info.IsDir() {
+		copyFile(t, src, dst)
+
+		return dst
+	}
+
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		copyFile(t, path, filepath.Join(dst, rel))
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dst
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+
+	content, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, dst, content)
+}
+
+func writeFile(t *testing.T, path string, content []byte) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
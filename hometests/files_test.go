@@ -0,0 +1,63 @@
+package hometests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTempFile(t *testing.T) {
+	t.Parallel()
+
+	path := TempFile(t, "config.yaml", "key: value")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "key: value", string(content))
+	assert.Equal(t, "config.yaml", filepath.Base(path))
+}
+
+func TestTempJSONFile(t *testing.T) {
+	t.Parallel()
+
+	path := TempJSONFile(t, map[string]int{"a": 1})
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(content))
+}
+
+func TestTempDirWithFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := TempDirWithFiles(t, map[string]string{
+		"a.txt":        "a",
+		"nested/b.txt": "b",
+	})
+
+	content, err := os.ReadFile(filepath.Join(dir, "nested", "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(content))
+}
+
+func TestCopyFixture(t *testing.T) {
+	t.Parallel()
+
+	src := TempDirWithFiles(t, map[string]string{"one.txt": "1", "sub/two.txt": "2"})
+
+	dst := CopyFixture(t, src)
+	require.NotEqual(t, src, dst)
+
+	content, err := os.ReadFile(filepath.Join(dst, "sub", "two.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(content))
+
+	file := CopyFixture(t, filepath.Join(src, "one.txt"))
+
+	content, err = os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(content))
+}
@@ -0,0 +1,102 @@
+package hometests
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const goldenDir = "testdata"
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// Golden compares got with the content of testdata/<name>.golden.
+// When the test binary is run with the -update flag (or UPDATE_GOLDEN=1), the file is rewritten with got instead.
+func Golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join(goldenDir, name+".golden")
+
+	if shouldUpdateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (run with -update to create it): %v", path, err)
+	}
+
+	if bytes.Equal(want, got) {
+		return
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(want)),
+		B:        difflib.SplitLines(string(got)),
+		FromFile: path,
+		ToFile:   "got",
+		Context:  3,
+	})
+
+	t.Errorf("result doesn't match golden file %s:\n%s", path, diff)
+}
+
+// GoldenJSON marshals v into indented JSON and compares it with the golden file.
+// Values that are already JSON ([]byte, json.RawMessage or string) are normalized,
+// so the key order and formatting don't affect the comparison.
+func GoldenJSON(t *testing.T, name string, v any) {
+	t.Helper()
+
+	got, err := normalizeJSON(v)
+	if err != nil {
+		t.Fatalf("failed to normalize JSON: %v", err)
+	}
+
+	Golden(t, name, got)
+}
+
+func normalizeJSON(v any) ([]byte, error) {
+	var raw []byte
+
+	switch val := v.(type) {
+	case []byte:
+		raw = val
+	case json.RawMessage:
+		raw = val
+	case string:
+		raw = []byte(val)
+	}
+
+	if raw != nil {
+		var decoded any
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return nil, err
+		}
+
+		v = decoded
+	}
+
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
+
+func shouldUpdateGolden() bool {
+	return *updateGolden || os.Getenv("UPDATE_GOLDEN") == "1"
+}
@@ -0,0 +1,32 @@
+package hometests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	Golden(t, "golden_plain", []byte("hello golden\n"))
+}
+
+func TestGoldenJSON(t *testing.T) {
+	t.Parallel()
+
+	GoldenJSON(t, "golden_json", map[string]any{"b": []string{"x", "y"}, "a": 1})
+	GoldenJSON(t, "golden_json", `{"b":["x","y"],"a":1}`)
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	t.Parallel()
+
+	got, err := normalizeJSON([]byte(`{"z":1,"a":{"c":true,"b":null}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": {\n    \"b\": null,\n    \"c\": true\n  },\n  \"z\": 1\n}\n", string(got))
+
+	_, err = normalizeJSON("{")
+	assert.Error(t, err)
+}
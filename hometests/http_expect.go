@@ -0,0 +1,207 @@
+package hometests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vmyroslav/home-lib/homehttp"
+)
+
+// HTTPExpect is a test client with fluent assertions on the responses.
+// It sends requests with homehttp.Client, so tests exercise the same client stack as production code.
+type HTTPExpect struct {
+	t       *testing.T
+	client  *homehttp.Client
+	baseURL string
+}
+
+// HTTPResponse is a received response with assertion methods, failures are reported with t.Errorf.
+type HTTPResponse struct {
+	t    *testing.T
+	resp *http.Response
+	body []byte
+}
+
+// NewHTTPExpect returns a new HTTPExpect sending requests to baseURL, the client is built with the given options,
+// e.g. homehttp.WithHeader or retry strategies.
+//
+//	e := hometests.NewHTTPExpect(t, server.URL)
+//	e.GET("/items/1").ExpectStatus(http.StatusOK).ExpectJSONField("id", 1)
+func NewHTTPExpect(t *testing.T, baseURL string, opts ...homehttp.ClientOption) *HTTPExpect {
+	t.Helper()
+
+	return &HTTPExpect{
+		t:       t,
+		client:  homehttp.NewClient(opts...),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// GET sends a GET request to the path.
+func (e *HTTPExpect) GET(path string) *HTTPResponse {
+	e.t.Helper()
+
+	return e.Do(http.MethodGet, path, nil)
+}
+
+// POST sends a POST request to the path with the payload encoded as JSON.
+func (e *HTTPExpect) POST(path string, payload any) *HTTPResponse {
+	e.t.Helper()
+
+	return e.Do(http.MethodPost, path, payload)
+}
+
+// PUT sends a PUT request to the path with the payload encoded as JSON.
+func (e *HTTPExpect) PUT(path string, payload any) *HTTPResponse {
+	e.t.Helper()
+
+	return e.Do(http.MethodPut, path, payload)
+}
+
+// DELETE sends a DELETE request to the path.
+func (e *HTTPExpect) DELETE(path string) *HTTPResponse {
+	e.t.Helper()
+
+	return e.Do(http.MethodDelete, path, nil)
+}
+
+// Do sends a request to the path with the payload encoded as JSON, if it is not nil.
+// The test fails immediately if no response is received.
+func (e *HTTPExpect) Do(method, path string, payload any) *HTTPResponse {
+	e.t.Helper()
+
+	resp, err := e.client.DoJSON(context.Background(), method, e.baseURL+path, payload)
+	if err != nil {
+		e.t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		e.t.Fatalf("failed to read response body of %s %s: %v", method, path, err)
+	}
+
+	return &HTTPResponse{t: e.t, resp: resp, body: body}
+}
+
+// Response returns the received response, its body is already consumed, see Body.
+func (r *HTTPResponse) Response() *http.Response {
+	return r.resp
+}
+
+// Body returns the response body.
+func (r *HTTPResponse) Body() []byte {
+	return r.body
+}
+
+// JSON decodes the response body into v, the test fails immediately if it is not possible.
+func (r *HTTPResponse) JSON(v any) *HTTPResponse {
+	r.t.Helper()
+
+	if err := json.Unmarshal(r.body, v); err != nil {
+		r.t.Fatalf("failed to decode response body %q: %v", r.body, err)
+	}
+
+	return r
+}
+
+// ExpectStatus checks the response status code.
+func (r *HTTPResponse) ExpectStatus(code int) *HTTPResponse {
+	r.t.Helper()
+
+	if r.resp.StatusCode != code {
+		r.t.Errorf("Expected status %d, but got %d, body: %s", code, r.resp.StatusCode, r.body)
+	}
+
+	return r
+}
+
+// ExpectHeader checks the value of the response header.
+func (r *HTTPResponse) ExpectHeader(key, value string) *HTTPResponse {
+	r.t.Helper()
+
+	if got := r.resp.Header.Get(key); got != value {
+		r.t.Errorf("Expected header %s to be %q, but got %q", key, value, got)
+	}
+
+	return r
+}
+
+// ExpectBodyContains checks that the response body contains the substring.
+func (r *HTTPResponse) ExpectBodyContains(substr string) *HTTPResponse {
+	r.t.Helper()
+
+	if !strings.Contains(string(r.body), substr) {
+		r.t.Errorf("Expected body to contain %q, but got %s", substr, r.body)
+	}
+
+	return r
+}
+
+// ExpectJSONField checks a field of the JSON response body. The path is dot-separated,
+// numbers select array elements, e.g. "items.0.id". Values are compared by their JSON form,
+// so ExpectJSONField("id", 1) matches {"id": 1}.
+func (r *HTTPResponse) ExpectJSONField(path string, want any) *HTTPResponse {
+	r.t.Helper()
+
+	var doc any
+	if err := json.Unmarshal(r.body, &doc); err != nil {
+		r.t.Errorf("Expected a JSON body, but got %s: %v", r.body, err)
+
+		return r
+	}
+
+	got, ok := jsonField(doc, path)
+	if !ok {
+		r.t.Errorf("Expected JSON field %q in %s", path, r.body)
+
+		return r
+	}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		r.t.Fatalf("failed to encode expected value %v: %v", want, err)
+	}
+
+	var normalized any
+
+	_ = json.Unmarshal(data, &normalized)
+
+	if !reflect.DeepEqual(got, normalized) {
+		r.t.Errorf("Expected JSON field %q to be %v, but got %v", path, normalized, got)
+	}
+
+	return r
+}
+
+func jsonField(doc any, path string) (any, bool) {
+	for _, key := range strings.Split(path, ".") {
+		switch v := doc.(type) {
+		case map[string]any:
+			field, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+
+			doc = field
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+
+			doc = v[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return doc, true
+}
@@ -0,0 +1,109 @@
+package hometests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vmyroslav/home-lib/homehttp"
+)
+
+func TestHTTPExpect(t *testing.T) {
+	t.Parallel()
+
+	server, capture := CaptureServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.Copy(w, r.Body)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = io.WriteString(w, `{"id":1,"name":"widget","tags":[{"name":"a"}],"price":9.5}`)
+		}
+	}))
+
+	e := NewHTTPExpect(t, server.URL+"/", homehttp.WithHeader("X-Test", "1"))
+
+	e.GET("/items/1").
+		ExpectStatus(http.StatusOK).
+		ExpectHeader("Content-Type", "application/json").
+		ExpectJSONField("id", 1).
+		ExpectJSONField("tags.0.name", "a").
+		ExpectJSONField("price", 9.5).
+		ExpectBodyContains("widget")
+
+	var created struct {
+		Name string `json:"name"`
+	}
+
+	e.POST("/items", map[string]string{"name": "gadget"}).ExpectStatus(http.StatusCreated).JSON(&created)
+
+	if created.Name != "gadget" {
+		t.Errorf("Expected echoed name gadget, but got %q", created.Name)
+	}
+
+	e.DELETE("/items/1").ExpectStatus(http.StatusNoContent)
+
+	capture.AssertCalled(t, 3)
+
+	if got := capture.LastRequest(t).Header.Get("X-Test"); got != "1" {
+		t.Errorf("Expected client header to be sent, but got %q", got)
+	}
+}
+
+func TestHTTPExpect_Retries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server, _ := CaptureServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+
+	NewHTTPExpect(t, server.URL,
+		homehttp.WithRetryStrategy(homehttp.RetryOn500x),
+		homehttp.WithMaxRetries(1),
+		homehttp.WithBackoffStrategy(homehttp.NoBackoff()),
+	).GET("/").ExpectStatus(http.StatusOK).ExpectJSONField("ok", true)
+}
+
+func TestJSONField(t *testing.T) {
+	t.Parallel()
+
+	var doc any
+	if err := json.Unmarshal([]byte(`{"id":1,"items":[{"name":"a"}],"empty":null}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path   string
+		want   any
+		wantOK bool
+	}{
+		{path: "id", want: float64(1), wantOK: true},
+		{path: "items.0.name", want: "a", wantOK: true},
+		{path: "empty", wantOK: true},
+		{path: "missing"},
+		{path: "id.x"},
+		{path: "items.1"},
+		{path: "items.x"},
+	}
+
+	for _, tt := range tests {
+		got, ok := jsonField(doc, tt.path)
+		if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("jsonField(%q) = %v, %v; want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package hometests
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const defaultLeakTimeout = time.Second
+
+// LeakOption configures VerifyNoLeaks.
+type LeakOption interface {
+	apply(cfg *leakConfig)
+}
+
+type leakOptionFn func(cfg *leakConfig)
+
+func (fn leakOptionFn) apply(cfg *leakConfig) {
+	fn(cfg)
+}
+
+type leakConfig struct {
+	ignored []string
+	timeout time.Duration
+}
+
+// IgnoreGoroutine ignores goroutines whose stack contains the given substring, e.g. a function name.
+func IgnoreGoroutine(substr string) LeakOption {
+	return leakOptionFn(func(cfg *leakConfig) {
+		cfg.ignored = append(cfg.ignored, substr)
+	})
+}
+
+// WithLeakTimeout sets how long to wait for goroutines to exit before reporting a leak.
+func WithLeakTimeout(timeout time.Duration) LeakOption {
+	return leakOptionFn(func(cfg *leakConfig) {
+		cfg.timeout = timeout
+	})
+}
+
+// VerifyNoLeaks snapshots running goroutines and fails the test at cleanup
+// if goroutines started during the test are still running.
+// It must not be used in parallel tests, since their goroutines would be reported as leaks.
+func VerifyNoLeaks(t *testing.T, opts ...LeakOption) {
+	t.Helper()
+
+	cfg := &leakConfig{timeout: defaultLeakTimeout}
+
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	before := map[string]bool{}
+	for _, g := range goroutines() {
+		before[g.id] = true
+	}
+
+	t.Cleanup(func() {
+		var leaked []goroutine
+
+		deadline := time.Now().Add(cfg.timeout)
+
+		for {
+			leaked = leaked[:0]
+
+			for _, g := range goroutines() {
+				if !before[g.id] && !cfg.isIgnored(g) {
+					leaked = append(leaked, g)
+				}
+			}
+
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		for _, g := range leaked {
+			t.Errorf("leaked goroutine:\n%s", g.stack)
+		}
+	})
+}
+
+func (cfg *leakConfig) isIgnored(g goroutine) bool {
+	for _, substr := range cfg.ignored {
+		if strings.Contains(g.stack, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type goroutine struct {
+	id    string
+	stack string
+}
+
+// goroutines returns all goroutines except the calling one.
+func goroutines() []goroutine {
+	buf := make([]byte, 1<<16)
+
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+
+	blocks := strings.Split(string(buf), "\n\n")
+	result := make([]goroutine, 0, len(blocks))
+
+	// the first goroutine is always the current one
+	for _, block := range blocks[1:] {
+		// header format: "goroutine 42 [running]:"
+		fields := strings.Fields(block)
+		if len(fields) < 2 || fields[0] != "goroutine" {
+			continue
+		}
+
+		result = append(result, goroutine{id: fields[1], stack: block})
+	}
+
+	return result
+}
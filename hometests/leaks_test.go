@@ -0,0 +1,51 @@
+package hometests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyNoLeaks(t *testing.T) { //nolint:paralleltest
+	VerifyNoLeaks(t)
+
+	done := make(chan struct{})
+
+	go func() {
+		<-done
+	}()
+
+	close(done)
+}
+
+func TestGoroutines(t *testing.T) {
+	t.Parallel()
+
+	started, stop := make(chan struct{}), make(chan struct{})
+	defer close(stop)
+
+	go blockingLeakTestFunc(started, stop)
+	<-started
+
+	cfg := &leakConfig{}
+	IgnoreGoroutine("blockingLeakTestFunc").apply(cfg)
+
+	var found bool
+
+	for _, g := range goroutines() {
+		if strings.Contains(g.stack, "blockingLeakTestFunc") {
+			found = true
+
+			assert.NotEmpty(t, g.id)
+			assert.True(t, cfg.isIgnored(g))
+		}
+	}
+
+	assert.True(t, found)
+}
+
+func blockingLeakTestFunc(started, stop chan struct{}) {
+	close(started)
+	<-stop
+}
@@ -0,0 +1,158 @@
+package hometests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// LogEntry is a single decoded JSON log entry.
+type LogEntry map[string]any
+
+// Level returns the level of the entry.
+func (e LogEntry) Level() string {
+	return e.String(zerolog.LevelFieldName)
+}
+
+// Message returns the message of the entry.
+func (e LogEntry) Message() string {
+	return e.String(zerolog.MessageFieldName)
+}
+
+// String returns the field value formatted as a string, or an empty string if there is no such field.
+func (e LogEntry) String(field string) string {
+	v, ok := e[field]
+	if !ok {
+		return ""
+	}
+
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(v)
+}
+
+// LogCapture is an io.Writer that collects JSON log lines written by zerolog,
+// e.g. via homelogger.WithOutput, and provides an API to query them.
+type LogCapture struct {
+	t       *testing.T
+	entries []LogEntry
+	pending []byte
+
+	mutex sync.Mutex
+}
+
+// CaptureLogs returns a new LogCapture.
+// The captured output is dumped to the test log if the test fails.
+func CaptureLogs(t *testing.T) *LogCapture {
+	t.Helper()
+
+	c := &LogCapture{t: t}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("captured logs:\n%s", c.dump())
+		}
+	})
+
+	return c
+}
+
+// Write implements io.Writer. Lines that are not valid JSON objects are stored as entries with a message only.
+func (c *LogCapture) Write(p []byte) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.pending = append(c.pending, p...)
+
+	for {
+		idx := bytes.IndexByte(c.pending, '\n')
+		if idx < 0 {
+			break
+		}
+
+		line := bytes.TrimSpace(c.pending[:idx])
+		c.pending = c.pending[idx+1:]
+
+		if len(line) == 0 {
+			continue
+		}
+
+		entry := LogEntry{}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			entry = LogEntry{zerolog.MessageFieldName: string(line)}
+		}
+
+		c.entries = append(c.entries, entry)
+	}
+
+	return len(p), nil
+}
+
+// Entries returns all captured entries.
+func (c *LogCapture) Entries() []LogEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]LogEntry(nil), c.entries...)
+}
+
+// ByLevel returns entries with the given level, e.g. "error".
+func (c *LogCapture) ByLevel(level string) []LogEntry {
+	return c.filter(func(e LogEntry) bool { return e.Level() == level })
+}
+
+// Containing returns entries whose message contains the substring.
+func (c *LogCapture) Containing(substr string) []LogEntry {
+	return c.filter(func(e LogEntry) bool { return strings.Contains(e.Message(), substr) })
+}
+
+// AsJSON returns all captured entries as a JSON array.
+func (c *LogCapture) AsJSON() []byte {
+	out, _ := json.Marshal(c.Entries())
+
+	return out
+}
+
+// AssertLogged fails the test if no entry with the given level contains the message substring.
+func (c *LogCapture) AssertLogged(t *testing.T, level, substr string) {
+	t.Helper()
+
+	for _, e := range c.ByLevel(level) {
+		if strings.Contains(e.Message(), substr) {
+			return
+		}
+	}
+
+	t.Errorf("Expected %s log entry containing %q, got:\n%s", level, substr, c.dump())
+}
+
+func (c *LogCapture) filter(fn func(e LogEntry) bool) []LogEntry {
+	var result []LogEntry
+
+	for _, e := range c.Entries() {
+		if fn(e) {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+func (c *LogCapture) dump() string {
+	var sb strings.Builder
+
+	for _, e := range c.Entries() {
+		line, _ := json.Marshal(e)
+		sb.Write(line)
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package hometests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmyroslav/home-lib/homelogger"
+)
+
+func TestCaptureLogs(t *testing.T) {
+	t.Parallel()
+
+	logs := CaptureLogs(t)
+	logger := homelogger.New(homelogger.WithOutput(logs), homelogger.WithApplicationName("test"))
+
+	logger.Info().Str("user", "alice").Msg("user logged in")
+	logger.Error().Int("attempt", 3).Msg("request failed")
+	logger.Info().Msg("done")
+
+	require.Len(t, logs.Entries(), 3)
+
+	infos := logs.ByLevel("info")
+	require.Len(t, infos, 2)
+	assert.Equal(t, "alice", infos[0].String("user"))
+	assert.Equal(t, "test", infos[0].String("application"))
+
+	failed := logs.Containing("failed")
+	require.Len(t, failed, 1)
+	assert.Equal(t, "error", failed[0].Level())
+	assert.Equal(t, "3", failed[0].String("attempt"))
+
+	logs.AssertLogged(t, "error", "request failed")
+
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(logs.AsJSON(), &decoded))
+	assert.Len(t, decoded, 3)
+}
+
+func TestLogCapture_PartialWrites(t *testing.T) {
+	t.Parallel()
+
+	logs := CaptureLogs(t)
+
+	_, _ = logs.Write([]byte(`{"level":"warn","mess`))
+	assert.Empty(t, logs.Entries())
+
+	_, _ = logs.Write([]byte("age\":\"slow\"}\nplain text\n"))
+
+	entries := logs.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "slow", entries[0].Message())
+	assert.Equal(t, "plain text", entries[1].Message())
+}
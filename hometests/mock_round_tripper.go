@@ -0,0 +1,228 @@
+package hometests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+var _ http.RoundTripper = (*MockRoundTripper)(nil)
+
+// MockRoundTripper is an http.RoundTripper that serves responses according to registered rules,
+// so HTTP clients can be tested without real servers.
+type MockRoundTripper struct {
+	t        *testing.T
+	rules    []*MockRule
+	fallback *mockResponse
+	calls    []*http.Request
+
+	mutex sync.Mutex
+}
+
+// MockRule matches requests by method and path and returns the scripted responses in order.
+type MockRule struct {
+	parent    *MockRoundTripper
+	method    string
+	path      string
+	responses []mockResponse
+	times     int
+	calls     int
+}
+
+type mockResponse struct {
+	err    error
+	header http.Header
+	body   []byte
+	status int
+}
+
+// NewMockRoundTripper returns a new MockRoundTripper without rules.
+func NewMockRoundTripper(t *testing.T) *MockRoundTripper {
+	t.Helper()
+
+	return &MockRoundTripper{t: t}
+}
+
+// On registers a new rule. An empty method matches any method.
+// Rules are matched in the registration order.
+func (m *MockRoundTripper) On(method, path string) *MockRule {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	r := &MockRule{parent: m, method: method, path: path}
+	m.rules = append(m.rules, r)
+
+	return r
+}
+
+// Default sets the response for requests that don't match any rule.
+// Without a default, such requests fail with an error.
+func (m *MockRoundTripper) Default(status int, body string) *MockRoundTripper {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.fallback = &mockResponse{status: status, body: []byte(body)}
+
+	return m
+}
+
+// DefaultError sets the error returned for requests that don't match any rule.
+func (m *MockRoundTripper) DefaultError(err error) *MockRoundTripper {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.fallback = &mockResponse{err: err}
+
+	return m
+}
+
+// Calls returns all requests received by the round tripper.
+func (m *MockRoundTripper) Calls() []*http.Request {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return append([]*http.Request(nil), m.calls...)
+}
+
+// AssertExpectations checks that every rule limited with Times has been called exactly that many times.
+func (m *MockRoundTripper) AssertExpectations(t *testing.T) {
+	t.Helper()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, r := range m.rules {
+		if r.times > 0 && r.calls != r.times {
+			t.Errorf("Expected %s %s to be called %d times, but got %d", r.method, r.path, r.times, r.calls)
+		}
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (m *MockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.calls = append(m.calls, req)
+
+	for _, r := range m.rules {
+		if r.matches(req) {
+			return r.next().build(req)
+		}
+	}
+
+	if m.fallback != nil {
+		return m.fallback.build(req)
+	}
+
+	return nil, fmt.Errorf("no mock rule matches %s %s", req.Method, req.URL)
+}
+
+// Return appends a response with the given status and body to the rule.
+func (r *MockRule) Return(status int, body string) *MockRule {
+	r.parent.mutex.Lock()
+	defer r.parent.mutex.Unlock()
+
+	r.responses = append(r.responses, mockResponse{status: status, body: []byte(body)})
+
+	return r
+}
+
+// ReturnJSON appends a response with the given status and JSON-encoded body to the rule.
+func (r *MockRule) ReturnJSON(status int, v any) *MockRule {
+	r.parent.t.Helper()
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		r.parent.t.Fatalf("failed to encode mock response: %v", err)
+	}
+
+	r.parent.mutex.Lock()
+	defer r.parent.mutex.Unlock()
+
+	r.responses = append(r.responses, mockResponse{
+		status: status,
+		body:   body,
+		header: http.Header{"Content-Type": []string{"application/json"}},
+	})
+
+	return r
+}
+
+// ReturnError appends a transport error to the rule.
+func (r *MockRule) ReturnError(err error) *MockRule {
+	r.parent.mutex.Lock()
+	defer r.parent.mutex.Unlock()
+
+	r.responses = append(r.responses, mockResponse{err: err})
+
+	return r
+}
+
+// Times limits the number of matches of the rule. Once exhausted, the rule no longer matches.
+func (r *MockRule) Times(n int) *MockRule {
+	r.parent.mutex.Lock()
+	defer r.parent.mutex.Unlock()
+
+	r.times = n
+
+	return r
+}
+
+// Calls returns the number of requests matched by the rule.
+func (r *MockRule) Calls() int {
+	r.parent.mutex.Lock()
+	defer r.parent.mutex.Unlock()
+
+	return r.calls
+}
+
+func (r *MockRule) matches(req *http.Request) bool {
+	if r.times > 0 && r.calls >= r.times {
+		return false
+	}
+
+	return (r.method == "" || r.method == req.Method) && r.path == req.URL.Path
+}
+
+// next returns the response for the current call, the last response is repeated.
+func (r *MockRule) next() mockResponse {
+	r.calls++
+
+	if len(r.responses) == 0 {
+		return mockResponse{status: http.StatusOK}
+	}
+
+	return r.responses[min(r.calls, len(r.responses))-1]
+}
+
+func (m mockResponse) build(req *http.Request) (*http.Response, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	header := m.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", m.status, http.StatusText(m.status)),
+		StatusCode:    m.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(m.body)),
+		ContentLength: int64(len(m.body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package hometests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockRoundTripper(t *testing.T) {
+	t.Parallel()
+
+	errConn := errors.New("connection refused")
+
+	m := NewMockRoundTripper(t)
+	users := m.On(http.MethodGet, "/users").ReturnJSON(http.StatusOK, []string{"alice"}).Times(2)
+	m.On(http.MethodPost, "/users").
+		Return(http.StatusInternalServerError, "").
+		Return(http.StatusCreated, `{"id":1}`)
+	m.On("", "/broken").ReturnError(errConn)
+	m.Default(http.StatusNotFound, "not found")
+
+	client := &http.Client{Transport: m}
+
+	do := func(method, path string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(context.Background(), method, "http://example.com"+path, http.NoBody)
+		require.NoError(t, err)
+
+		return client.Do(req)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := do(http.MethodGet, "/users")
+		require.NoError(t, err)
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `["alice"]`, string(body))
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	}
+
+	// the rule is exhausted, so the default response is served
+	resp, err := do(http.MethodGet, "/users")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	for _, want := range []int{http.StatusInternalServerError, http.StatusCreated, http.StatusCreated} {
+		resp, err = do(http.MethodPost, "/users")
+		require.NoError(t, err)
+		assert.Equal(t, want, resp.StatusCode)
+	}
+
+	_, err = do(http.MethodDelete, "/broken")
+	require.ErrorIs(t, err, errConn)
+
+	assert.Equal(t, 2, users.Calls())
+	assert.Len(t, m.Calls(), 7)
+	m.AssertExpectations(t)
+}
+
+func TestMockRoundTripper_NoMatch(t *testing.T) {
+	t.Parallel()
+
+	m := NewMockRoundTripper(t)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/", http.NoBody)
+	require.NoError(t, err)
+
+	_, err = m.RoundTrip(req)
+	assert.Error(t, err)
+}
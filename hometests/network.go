@@ -1,20 +1,32 @@
 package hometests
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/vmyroslav/home-lib/homemath"
 )
 
 // RandomPort generates a random port, trying up to maxRetries times.
 func RandomPort(t *testing.T) int {
 	t.Helper()
 
+	return RandomPortWith(t, rand.New(rand.NewSource(time.Now().UnixNano()))) //nolint:gosec
+}
+
+// RandomPortWith is like RandomPort, but picks ports with the given source of randomness, see SeededRand.
+func RandomPortWith(t *testing.T, r homemath.Randomizer) int {
+	t.Helper()
+
 	maxRetries := 5
 
-	port, err := randomPort(rand.New(rand.NewSource(time.Now().UnixNano())), defaultListener{}, maxRetries) //nolint:gosec
+	port, err := randomPort(r, defaultListener{}, maxRetries)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -50,3 +62,60 @@ type defaultListener struct{}
 func (d defaultListener) Listen(network, address string) (net.Listener, error) {
 	return net.Listen(network, address)
 }
+
+// ReservePort binds a listener to a free local port and returns it together with the port number.
+// Unlike RandomPort, the port stays reserved until the listener is handed to a server or the test finishes,
+// so no other process can grab it in between.
+func ReservePort(t *testing.T) (net.Listener, int) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	addr, ok := l.Addr().(*net.TCPAddr)
+	if !ok {
+		t.Fatalf("unexpected listener address type %T", l.Addr())
+	}
+
+	return l, addr.Port
+}
+
+// NewTestServerOnListener starts an httptest.Server on the given listener, e.g. the one from ReservePort.
+// The server is closed automatically when the test finishes.
+func NewTestServerOnListener(t *testing.T, l net.Listener, handler http.Handler) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(handler)
+	_ = server.Listener.Close()
+	server.Listener = l
+	server.Start()
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// ServeOnListener runs a real http.Server with the handler on the given listener in the background.
+// The server is shut down automatically when the test finishes.
+func ServeOnListener(t *testing.T, l net.Listener, handler http.Handler) *http.Server {
+	t.Helper()
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		_ = server.Serve(l)
+	}()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = server.Shutdown(ctx)
+	})
+
+	return server
+}
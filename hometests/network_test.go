@@ -2,7 +2,9 @@ package hometests
 
 import (
 	"errors"
+	"fmt"
 	"net"
+	"net/http"
 	"testing"
 )
 
@@ -93,3 +95,45 @@ func (m *mockListener) Listen(_, _ string) (net.Listener, error) {
 
 	return &net.TCPListener{}, nil
 }
+
+func TestReservePort(t *testing.T) {
+	t.Parallel()
+
+	l, port := ReservePort(t)
+
+	if port <= 0 {
+		t.Fatalf("expected positive port, got %d", port)
+	}
+
+	// the port is taken until the listener is closed
+	if conn, err := net.Listen("tcp", l.Addr().String()); err == nil {
+		_ = conn.Close()
+		t.Fatal("expected the port to be reserved")
+	}
+
+	server := NewTestServerOnListener(t, l, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	if want := fmt.Sprintf("http://127.0.0.1:%d", port); server.URL != want {
+		t.Errorf("expected server URL %s, got %s", want, server.URL)
+	}
+
+	if status := doRequest(t, http.MethodGet, server.URL).StatusCode; status != http.StatusTeapot {
+		t.Errorf("expected status %d, got %d", http.StatusTeapot, status)
+	}
+}
+
+func TestServeOnListener(t *testing.T) {
+	t.Parallel()
+
+	l, port := ReservePort(t)
+
+	ServeOnListener(t, l, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	if status := doRequest(t, http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d", port)).StatusCode; status != http.StatusAccepted {
+		t.Errorf("expected status %d, got %d", http.StatusAccepted, status)
+	}
+}
@@ -0,0 +1,274 @@
+package hometests
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // ETags of S3 are MD5 digests
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ObjectStore is an in-memory object storage served by ObjectStoreServer with an S3-compatible API.
+// It can be inspected and prepared directly while the server is running.
+type ObjectStore struct {
+	buckets map[string]map[string]storedObject
+
+	mutex sync.RWMutex
+}
+
+type storedObject struct {
+	modified    time.Time
+	contentType string
+	etag        string
+	data        []byte
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	Contents              []listedObject `xml:"Contents"`
+	KeyCount              int            `xml:"KeyCount"`
+	MaxKeys               int            `xml:"MaxKeys"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+}
+
+type listedObject struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+}
+
+type objectStoreError struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+const defaultMaxKeys = 1000
+
+// ObjectStoreServer starts a test server with a minimal S3-compatible API using path-style addressing:
+// PUT /bucket creates a bucket, PUT, GET, HEAD and DELETE /bucket/key manage objects,
+// GET /bucket?list-type=2 lists them with prefix, max-keys and continuation-token support.
+// Objects have MD5 ETags, GET and HEAD honor Range and conditional headers. Authentication is not checked.
+// The server is closed automatically when the test finishes.
+func ObjectStoreServer(t *testing.T) (*httptest.Server, *ObjectStore) {
+	t.Helper()
+
+	store := &ObjectStore{buckets: make(map[string]map[string]storedObject)}
+
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+// CreateBucket creates an empty bucket, an existing bucket is kept as is.
+func (s *ObjectStore) CreateBucket(bucket string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.buckets[bucket]; !ok {
+		s.buckets[bucket] = make(map[string]storedObject)
+	}
+}
+
+// PutObject stores an object, creating the bucket if needed.
+func (s *ObjectStore) PutObject(bucket, key string, data []byte) {
+	s.CreateBucket(bucket)
+	s.put(bucket, key, data, "")
+}
+
+// Object returns the content of an object and whether it exists.
+func (s *ObjectStore) Object(bucket, key string) ([]byte, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	obj, ok := s.buckets[bucket][key]
+
+	return obj.data, ok
+}
+
+// Keys returns the sorted keys of all objects in the bucket.
+func (s *ObjectStore) Keys(bucket string) []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	keys := make([]string, 0, len(s.buckets[bucket]))
+	for key := range s.buckets[bucket] {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ServeHTTP implements the S3-compatible API.
+func (s *ObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	if bucket == "" {
+		writeObjectStoreError(w, http.StatusBadRequest, "InvalidBucketName", "bucket is not specified")
+		return
+	}
+
+	if key == "" {
+		s.serveBucket(w, r, bucket)
+		return
+	}
+
+	s.serveObject(w, r, bucket, key)
+}
+
+func (s *ObjectStore) serveBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	switch r.Method {
+	case http.MethodPut:
+		s.CreateBucket(bucket)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		s.list(w, r, bucket)
+	default:
+		writeObjectStoreError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method+" is not supported")
+	}
+}
+
+func (s *ObjectStore) serveObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	s.mutex.RLock()
+	objects, ok := s.buckets[bucket]
+	obj, found := objects[key]
+	s.mutex.RUnlock()
+
+	if !ok {
+		writeObjectStoreError(w, http.StatusNotFound, "NoSuchBucket", "the bucket "+bucket+" does not exist")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeObjectStoreError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+
+		w.Header().Set("ETag", s.put(bucket, key, data, r.Header.Get("Content-Type")))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		if !found {
+			writeObjectStoreError(w, http.StatusNotFound, "NoSuchKey", "the key "+key+" does not exist")
+			return
+		}
+
+		w.Header().Set("ETag", obj.etag)
+		w.Header().Set("Content-Type", obj.contentType)
+		http.ServeContent(w, r, "", obj.modified, bytes.NewReader(obj.data))
+	case http.MethodDelete:
+		s.mutex.Lock()
+		delete(s.buckets[bucket], key)
+		s.mutex.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeObjectStoreError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method+" is not supported")
+	}
+}
+
+func (s *ObjectStore) list(w http.ResponseWriter, r *http.Request, bucket string) {
+	s.mutex.RLock()
+	_, ok := s.buckets[bucket]
+	s.mutex.RUnlock()
+
+	if !ok {
+		writeObjectStoreError(w, http.StatusNotFound, "NoSuchBucket", "the bucket "+bucket+" does not exist")
+		return
+	}
+
+	query := r.URL.Query()
+
+	maxKeys := defaultMaxKeys
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeObjectStoreError(w, http.StatusBadRequest, "InvalidArgument", "invalid max-keys "+v)
+			return
+		}
+
+		maxKeys = n
+	}
+
+	result := listBucketResult{Name: bucket, Prefix: query.Get("prefix"), MaxKeys: maxKeys}
+	after := query.Get("continuation-token")
+	keys := s.Keys(bucket)
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, key := range keys {
+		obj, found := s.buckets[bucket][key]
+		if !found || !strings.HasPrefix(key, result.Prefix) || key <= after {
+			continue
+		}
+
+		if len(result.Contents) == maxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = result.Contents[len(result.Contents)-1].Key
+
+			break
+		}
+
+		result.Contents = append(result.Contents, listedObject{
+			Key:          key,
+			LastModified: obj.modified.UTC().Format(time.RFC3339),
+			ETag:         obj.etag,
+			Size:         len(obj.data),
+		})
+	}
+
+	result.KeyCount = len(result.Contents)
+
+	writeXML(w, http.StatusOK, result)
+}
+
+func (s *ObjectStore) put(bucket, key string, data []byte, contentType string) string {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	sum := md5.Sum(data) //nolint:gosec
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.buckets[bucket][key] = storedObject{
+		modified:    time.Now().Truncate(time.Second),
+		contentType: contentType,
+		etag:        etag,
+		data:        append([]byte(nil), data...),
+	}
+
+	return etag
+}
+
+func writeObjectStoreError(w http.ResponseWriter, status int, code, message string) {
+	writeXML(w, status, objectStoreError{Code: code, Message: message})
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,114 @@
+package hometests
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func objectStoreRequest(t *testing.T, method, url, body string, header http.Header) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(data)
+}
+
+func TestObjectStoreServer(t *testing.T) {
+	t.Parallel()
+
+	server, store := ObjectStoreServer(t)
+
+	resp, _ := objectStoreRequest(t, http.MethodPut, server.URL+"/bucket/missing", "data", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	objectStoreRequest(t, http.MethodPut, server.URL+"/bucket", "", nil)
+
+	resp, _ = objectStoreRequest(t, http.MethodPut, server.URL+"/bucket/dir/file.txt", "hello world",
+		http.Header{"Content-Type": {"text/plain"}})
+	etag := resp.Header.Get("ETag")
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"5eb63bbbe01eeed093cb22bb8f5acdc3"`, etag)
+
+	resp, body := objectStoreRequest(t, http.MethodGet, server.URL+"/bucket/dir/file.txt", "", nil)
+	assert.Equal(t, "hello world", body)
+	assert.Equal(t, "text/plain", resp.Header.Get("Content-Type"))
+
+	resp, body = objectStoreRequest(t, http.MethodGet, server.URL+"/bucket/dir/file.txt", "",
+		http.Header{"Range": {"bytes=6-"}})
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "world", body)
+
+	resp, _ = objectStoreRequest(t, http.MethodGet, server.URL+"/bucket/dir/file.txt", "",
+		http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	store.PutObject("bucket", "other.txt", []byte("x"))
+
+	resp, _ = objectStoreRequest(t, http.MethodDelete, server.URL+"/bucket/other.txt", "", nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	_, ok := store.Object("bucket", "other.txt")
+	assert.False(t, ok)
+
+	resp, body = objectStoreRequest(t, http.MethodGet, server.URL+"/bucket/other.txt", "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, body, "NoSuchKey")
+}
+
+func TestObjectStoreServer_List(t *testing.T) {
+	t.Parallel()
+
+	server, store := ObjectStoreServer(t)
+
+	for _, key := range []string{"logs/1", "logs/2", "logs/3", "data/1"} {
+		store.PutObject("bucket", key, []byte(key))
+	}
+
+	list := func(query string) listBucketResult {
+		t.Helper()
+
+		_, body := objectStoreRequest(t, http.MethodGet, server.URL+"/bucket?list-type=2&"+query, "", nil)
+
+		var result listBucketResult
+		require.NoError(t, xml.Unmarshal([]byte(body), &result))
+
+		return result
+	}
+
+	first := list("prefix=logs/&max-keys=2")
+	assert.Equal(t, 2, first.KeyCount)
+	assert.True(t, first.IsTruncated)
+	assert.Equal(t, "logs/2", first.NextContinuationToken)
+
+	second := list("prefix=logs/&max-keys=2&continuation-token=" + first.NextContinuationToken)
+	require.Equal(t, 1, second.KeyCount)
+	assert.False(t, second.IsTruncated)
+	assert.Equal(t, "logs/3", second.Contents[0].Key)
+
+	all := list("")
+	require.Equal(t, 4, all.KeyCount)
+	assert.Equal(t, "data/1", all.Contents[0].Key)
+	assert.Equal(t, len("data/1"), all.Contents[0].Size)
+	assert.Equal(t, []string{"data/1", "logs/1", "logs/2", "logs/3"}, store.Keys("bucket"))
+}
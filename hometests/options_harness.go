@@ -0,0 +1,109 @@
+package hometests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// OptionCase is a case of OptionsTest: the options applied to a fresh config and the expected result.
+// Want holds the expected values by dot-separated field paths, e.g. "Retry.Max"; unexported fields are supported.
+// If WantConfig is set, every field of the config is compared with it instead; func fields are skipped.
+type OptionCase[C, O any] struct {
+	Name       string
+	Options    []O
+	Want       map[string]any
+	WantConfig *C
+}
+
+// OptionsTest runs every case as a subtest: it applies the options to a config returned by newConfig
+// with apply and reports every field that differs from the expected value.
+//
+//	hometests.OptionsTest(t, newDefaultConfig, func(c *config, o Option) { o.apply(c) }, []hometests.OptionCase[config, Option]{
+//		{Name: "ttl", Options: []Option{WithTTL(time.Minute)}, Want: map[string]any{"ttl": time.Minute}},
+//	})
+func OptionsTest[C, O any](t *testing.T, newConfig func() *C, apply func(*C, O), cases []OptionCase[C, O]) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+
+			cfg := newConfig()
+			for _, opt := range tc.Options {
+				apply(cfg, opt)
+			}
+
+			v := reflect.ValueOf(cfg).Elem()
+
+			if tc.WantConfig != nil {
+				diffFields(t, "", v, reflect.ValueOf(tc.WantConfig).Elem())
+			}
+
+			for path, want := range tc.Want {
+				field, ok := fieldByPath(v, path)
+				if !ok {
+					t.Errorf("field %q not found in %s", path, v.Type())
+
+					continue
+				}
+
+				if got := valueOf(field); !reflect.DeepEqual(got, want) {
+					t.Errorf("field %s: expected %#v, but got %#v", path, want, got)
+				}
+			}
+		})
+	}
+}
+
+// diffFields reports the differing fields of two struct values, recursing into nested structs.
+func diffFields(t *testing.T, prefix string, got, want reflect.Value) {
+	t.Helper()
+
+	for i := 0; i < got.NumField(); i++ {
+		name := prefix + got.Type().Field(i).Name
+		g, w := got.Field(i), want.Field(i)
+
+		switch g.Kind() { //nolint:exhaustive
+		case reflect.Func:
+			continue
+		case reflect.Struct:
+			diffFields(t, name+".", g, w)
+
+			continue
+		}
+
+		if gv, wv := valueOf(g), valueOf(w); !reflect.DeepEqual(gv, wv) {
+			t.Errorf("field %s: expected %#v, but got %#v", name, wv, gv)
+		}
+	}
+}
+
+func fieldByPath(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+
+	return v, true
+}
+
+// valueOf returns the value of an addressable field, reading unexported fields as well.
+func valueOf(v reflect.Value) any {
+	if !v.CanInterface() {
+		v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem() //nolint:gosec
+	}
+
+	return v.Interface()
+}
@@ -0,0 +1,75 @@
+package hometests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type harnessRetry struct {
+	Max  int
+	wait time.Duration
+}
+
+type harnessConfig struct {
+	name    string
+	Timeout time.Duration
+	Tags    []string
+	Retry   harnessRetry
+	hook    func()
+}
+
+type harnessOption func(*harnessConfig)
+
+func TestOptionsTest(t *testing.T) {
+	t.Parallel()
+
+	withName := func(name string) harnessOption { return func(c *harnessConfig) { c.name = name } }
+	withRetry := func(n int, wait time.Duration) harnessOption {
+		return func(c *harnessConfig) { c.Retry = harnessRetry{Max: n, wait: wait} }
+	}
+	withTags := func(tags ...string) harnessOption { return func(c *harnessConfig) { c.Tags = tags } }
+
+	newConfig := func() *harnessConfig {
+		return &harnessConfig{Timeout: time.Second, hook: func() {}}
+	}
+
+	OptionsTest(t, newConfig, func(c *harnessConfig, o harnessOption) { o(c) }, []OptionCase[harnessConfig, harnessOption]{
+		{
+			Name:    "fields by path",
+			Options: []harnessOption{withName("svc"), withRetry(3, time.Millisecond)},
+			Want: map[string]any{
+				"name":       "svc",
+				"Timeout":    time.Second,
+				"Retry.Max":  3,
+				"Retry.wait": time.Millisecond,
+			},
+		},
+		{
+			Name:    "whole config",
+			Options: []harnessOption{withTags("a", "b")},
+			WantConfig: &harnessConfig{
+				Timeout: time.Second,
+				Tags:    []string{"a", "b"},
+			},
+		},
+	})
+}
+
+func TestFieldByPath(t *testing.T) {
+	t.Parallel()
+
+	cfg := &harnessConfig{Retry: harnessRetry{Max: 1}}
+	v := reflect.ValueOf(cfg).Elem()
+
+	for _, path := range []string{"missing", "Retry.missing", "name.x"} {
+		if _, ok := fieldByPath(v, path); ok {
+			t.Errorf("Expected path %q not to be found", path)
+		}
+	}
+
+	field, ok := fieldByPath(v, "Retry.Max")
+	if !ok || valueOf(field) != 1 {
+		t.Errorf("Expected Retry.Max to be 1, but got %v", field)
+	}
+}
@@ -0,0 +1,41 @@
+package hometests
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// seedEnv overrides the seed chosen by SeededRand, e.g. to replay a failed run.
+const seedEnv = "HOMETESTS_SEED"
+
+// SeededRand returns a random source seeded with seed, it implements homemath.Randomizer.
+// A zero seed is taken from the HOMETESTS_SEED variable or, if it is not set, from the current time.
+// The seed is reported if the test fails, so the run can be reproduced with HOMETESTS_SEED.
+// The source is not safe for concurrent use.
+func SeededRand(t *testing.T, seed int64) *rand.Rand {
+	t.Helper()
+
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+
+		if v := os.Getenv(seedEnv); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				t.Fatalf("invalid %s %q: %v", seedEnv, v, err)
+			}
+
+			seed = parsed
+		}
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("random seed: %d, rerun with %s=%d to reproduce", seed, seedEnv, seed)
+		}
+	})
+
+	return rand.New(rand.NewSource(seed)) //nolint:gosec
+}
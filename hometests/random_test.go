@@ -0,0 +1,40 @@
+package hometests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vmyroslav/home-lib/homemath"
+)
+
+func TestSeededRand(t *testing.T) {
+	t.Parallel()
+
+	first, second := SeededRand(t, 42), SeededRand(t, 42)
+
+	for range 10 {
+		if a, b := first.Int63(), second.Int63(); a != b {
+			t.Fatalf("Expected the same sequence for the same seed, but got %d and %d", a, b)
+		}
+	}
+
+	if SeededRand(t, 0) == nil {
+		t.Fatal("Expected a random source for the zero seed")
+	}
+}
+
+func TestSeededRand_Homemath(t *testing.T) {
+	t.Parallel()
+
+	jitter := func() time.Duration {
+		return homemath.DurationJitterWith(SeededRand(t, 7), time.Second, 0.5)
+	}
+
+	if a, b := jitter(), jitter(); a != b {
+		t.Errorf("Expected reproducible jitter, but got %s and %s", a, b)
+	}
+
+	if port := RandomPortWith(t, SeededRand(t, 7)); port < 1024 {
+		t.Errorf("Expected a non-privileged port, but got %d", port)
+	}
+}
@@ -0,0 +1,83 @@
+package hometests
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// RateLimitedServer starts a test server that allows limit requests per fixed window
+// and responds with 429 Too Many Requests once the limit is exceeded.
+// Every response carries X-RateLimit-* and IETF RateLimit-* headers, rejected ones also carry Retry-After.
+// The server is closed automatically when the test finishes.
+func RateLimitedServer(t *testing.T, limit int, window time.Duration) *httptest.Server {
+	t.Helper()
+
+	l := &fixedWindowLimiter{limit: limit, window: window, now: time.Now}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		allowed, remaining, reset := l.take()
+
+		resetSeconds := strconv.Itoa(int(math.Ceil(time.Until(reset).Seconds())))
+
+		h := w.Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		h.Set("RateLimit-Limit", strconv.Itoa(limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("RateLimit-Reset", resetSeconds)
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit, int(math.Ceil(window.Seconds()))))
+
+		if !allowed {
+			h.Set("Retry-After", resetSeconds)
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+type fixedWindowLimiter struct {
+	windowStart time.Time
+	now         func() time.Time
+	window      time.Duration
+	limit       int
+	used        int
+
+	mutex sync.Mutex
+}
+
+// take consumes a request from the current window.
+// It returns whether the request is allowed, the number of remaining requests and the window reset time.
+func (l *fixedWindowLimiter) take() (bool, int, time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	if l.windowStart.IsZero() || !now.Before(l.windowStart.Add(l.window)) {
+		l.windowStart = now
+		l.used = 0
+	}
+
+	reset := l.windowStart.Add(l.window)
+
+	if l.used >= l.limit {
+		return false, 0, reset
+	}
+
+	l.used++
+
+	return true, l.limit - l.used, reset
+}
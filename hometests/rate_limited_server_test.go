@@ -0,0 +1,59 @@
+package hometests
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedServer(t *testing.T) {
+	t.Parallel()
+
+	s := RateLimitedServer(t, 2, 100*time.Millisecond)
+
+	for _, wantRemaining := range []string{"1", "0"} {
+		resp := doRequest(t, http.MethodGet, s.URL)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-RateLimit-Limit"))
+		assert.Equal(t, wantRemaining, resp.Header.Get("X-RateLimit-Remaining"))
+		assert.Equal(t, wantRemaining, resp.Header.Get("RateLimit-Remaining"))
+		assert.Empty(t, resp.Header.Get("Retry-After"))
+	}
+
+	resp := doRequest(t, http.MethodGet, s.URL)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "2;w=1", resp.Header.Get("RateLimit-Policy"))
+
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Unix(), reset, 1)
+
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, http.StatusOK, doRequest(t, http.MethodGet, s.URL).StatusCode)
+}
+
+func TestFixedWindowLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	l := &fixedWindowLimiter{limit: 1, window: time.Minute, now: func() time.Time { return now }}
+
+	allowed, remaining, reset := l.take()
+	assert.True(t, allowed)
+	assert.Equal(t, 0, remaining)
+	assert.Equal(t, now.Add(time.Minute), reset)
+
+	allowed, _, _ = l.take()
+	assert.False(t, allowed)
+
+	now = now.Add(time.Minute)
+
+	allowed, _, _ = l.take()
+	assert.True(t, allowed)
+}
@@ -0,0 +1,91 @@
+package hometests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	readinessInitialInterval = 10 * time.Millisecond
+	readinessMaxInterval     = time.Second
+)
+
+// WaitForTCP blocks until a TCP connection to addr can be established.
+// The test fails if it doesn't happen within the timeout.
+func WaitForTCP(t *testing.T, addr string, timeout time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+
+	err := pollUntilReady(ctx, func(ctx context.Context) error {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	})
+	if err != nil {
+		t.Fatalf("%s is not reachable within %s: %v", addr, timeout, err)
+	}
+}
+
+// WaitForHTTP blocks until a GET request to url responds with the expected status.
+// The test fails if it doesn't happen within the timeout.
+func WaitForHTTP(t *testing.T, url string, expectedStatus int, timeout time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := pollUntilReady(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != expectedStatus {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%s is not ready within %s: %v", url, timeout, err)
+	}
+}
+
+// pollUntilReady calls check with exponentially growing intervals until it succeeds or ctx is done.
+// Returns the last check error if ctx is done first.
+func pollUntilReady(ctx context.Context, check func(ctx context.Context) error) error {
+	interval := readinessInitialInterval
+
+	for {
+		err := check(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: last error: %w", ctx.Err(), err)
+		case <-time.After(interval):
+		}
+
+		interval = min(2*interval, readinessMaxInterval)
+	}
+}
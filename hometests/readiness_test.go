@@ -0,0 +1,72 @@
+package hometests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForTCPAndHTTP(t *testing.T) {
+	t.Parallel()
+
+	var ready atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !ready.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	WaitForTCP(t, server.Listener.Addr().String(), time.Second)
+
+	time.AfterFunc(30*time.Millisecond, func() { ready.Store(true) })
+
+	WaitForHTTP(t, server.URL, http.StatusOK, time.Second)
+}
+
+func TestPollUntilReady(t *testing.T) {
+	t.Parallel()
+
+	errNotReady := errors.New("not ready")
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		err := pollUntilReady(context.Background(), func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errNotReady
+			}
+
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("fails on timeout", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		err := pollUntilReady(ctx, func(context.Context) error { return errNotReady })
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, err, errNotReady)
+	})
+}
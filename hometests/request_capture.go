@@ -0,0 +1,177 @@
+package hometests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// CapturedRequest is a snapshot of a request received by a test server.
+type CapturedRequest struct {
+	Header http.Header
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// RequestCapture records requests passing through its handler.
+// It is safe to inspect the captured requests while the server is still handling new ones.
+type RequestCapture struct {
+	requests []CapturedRequest
+	changed  chan struct{}
+
+	mutex sync.Mutex
+}
+
+// NewRequestCapture returns a new empty RequestCapture.
+func NewRequestCapture() *RequestCapture {
+	return &RequestCapture{changed: make(chan struct{})}
+}
+
+// CaptureServer starts a test server that records every request and passes it to the handler.
+// If the handler is nil, the server responds with 200 OK.
+// The server is closed automatically when the test finishes.
+func CaptureServer(t *testing.T, handler http.Handler) (*httptest.Server, *RequestCapture) {
+	t.Helper()
+
+	capture := NewRequestCapture()
+
+	server := httptest.NewServer(capture.Handler(handler))
+	t.Cleanup(server.Close)
+
+	return server, capture
+}
+
+// Handler returns a handler that records the request and passes it to next.
+// If next is nil, the handler responds with 200 OK.
+func (c *RequestCapture) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		c.record(CapturedRequest{
+			Header: req.Header.Clone(),
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.RawQuery,
+			Body:   body,
+		})
+
+		if next == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// Requests returns a copy of all captured requests.
+func (c *RequestCapture) Requests() []CapturedRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]CapturedRequest(nil), c.requests...)
+}
+
+// Count returns the number of captured requests.
+func (c *RequestCapture) Count() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.requests)
+}
+
+// AssertCalled checks that exactly the given number of requests has been captured.
+func (c *RequestCapture) AssertCalled(t *testing.T, times int) {
+	t.Helper()
+
+	if count := c.Count(); count != times {
+		t.Errorf("Expected %d requests, but got %d", times, count)
+	}
+}
+
+// LastRequest returns the most recent captured request.
+// The test fails if no requests have been captured.
+func (c *RequestCapture) LastRequest(t *testing.T) CapturedRequest {
+	t.Helper()
+
+	requests := c.Requests()
+	if len(requests) == 0 {
+		t.Fatal("no requests captured")
+	}
+
+	return requests[len(requests)-1]
+}
+
+// Request returns the i-th captured request.
+// The test fails if there is no such request.
+func (c *RequestCapture) Request(t *testing.T, i int) CapturedRequest {
+	t.Helper()
+
+	requests := c.Requests()
+	if i < 0 || i >= len(requests) {
+		t.Fatalf("request %d not captured, got %d requests", i, len(requests))
+	}
+
+	return requests[i]
+}
+
+// JSONBodyOf decodes the body of the i-th captured request into v.
+func (c *RequestCapture) JSONBodyOf(t *testing.T, i int, v any) {
+	t.Helper()
+
+	if err := json.Unmarshal(c.Request(t, i).Body, v); err != nil {
+		t.Fatalf("failed to decode body of request %d: %v", i, err)
+	}
+}
+
+// HeadersOf returns the headers of the i-th captured request.
+func (c *RequestCapture) HeadersOf(t *testing.T, i int) http.Header {
+	t.Helper()
+
+	return c.Request(t, i).Header
+}
+
+// WaitForRequests blocks until at least n requests are captured.
+// The test fails if it doesn't happen within the timeout.
+func (c *RequestCapture) WaitForRequests(t *testing.T, n int, timeout time.Duration) {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		c.mutex.Lock()
+		count, changed := len(c.requests), c.changed
+		c.mutex.Unlock()
+
+		if count >= n {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			t.Fatalf("Expected %d requests within %s, but got %d", n, timeout, count)
+		}
+	}
+}
+
+func (c *RequestCapture) record(req CapturedRequest) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.requests = append(c.requests, req)
+
+	// wake up all waiters
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
@@ -0,0 +1,73 @@
+package hometests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestCapture(t *testing.T) {
+	t.Parallel()
+
+	server, capture := CaptureServer(t, nil)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, server.URL+"/users?page=2", bytes.NewBufferString(`{"name":"test"}`),
+	)
+	require.NoError(t, err)
+	req.Header.Set("X-Id", "42")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	capture.AssertCalled(t, 1)
+
+	last := capture.LastRequest(t)
+	assert.Equal(t, http.MethodPost, last.Method)
+	assert.Equal(t, "/users", last.Path)
+	assert.Equal(t, "page=2", last.Query)
+	assert.Equal(t, "42", capture.HeadersOf(t, 0).Get("X-Id"))
+
+	var body struct {
+		Name string `json:"name"`
+	}
+
+	capture.JSONBodyOf(t, 0, &body)
+	assert.Equal(t, "test", body.Name)
+}
+
+func TestRequestCapture_WaitForRequests(t *testing.T) {
+	t.Parallel()
+
+	server, capture := CaptureServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() { //nolint:wsl
+			defer wg.Done()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+
+	capture.WaitForRequests(t, 5, time.Second)
+	wg.Wait()
+
+	assert.Len(t, capture.Requests(), 5)
+}
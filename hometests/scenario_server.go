@@ -0,0 +1,182 @@
+package hometests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ScenarioServer is an HTTP test server that serves scripted responses per route.
+// The server is closed automatically when the test finishes.
+type ScenarioServer struct {
+	*httptest.Server
+
+	routes    []*Route
+	unmatched []*http.Request
+
+	mutex sync.Mutex
+}
+
+// Route is a method and path matcher with an ordered list of scripted responses.
+type Route struct {
+	method    string
+	path      string
+	responses []ScriptedResponse
+	latency   time.Duration
+	calls     int
+
+	mutex sync.Mutex
+}
+
+// ScriptedResponse describes a single response served by a Route.
+type ScriptedResponse struct {
+	Headers map[string]string
+	Body    string
+	Status  int
+	Delay   time.Duration
+}
+
+// NewScenarioServer starts a new ScenarioServer.
+// Requests that don't match any route are answered with 404 Not Found.
+func NewScenarioServer(t *testing.T) *ScenarioServer {
+	t.Helper()
+
+	s := &ScenarioServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// On registers a new route. An empty method matches any method.
+// Routes are matched in the registration order.
+func (s *ScenarioServer) On(method, path string) *Route {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r := &Route{method: method, path: path}
+	s.routes = append(s.routes, r)
+
+	return r
+}
+
+// Unmatched returns requests that didn't match any route.
+func (s *ScenarioServer) Unmatched() []*http.Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*http.Request(nil), s.unmatched...)
+}
+
+func (s *ScenarioServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	route := s.match(req)
+	if route == nil {
+		s.mutex.Lock()
+		s.unmatched = append(s.unmatched, req)
+		s.mutex.Unlock()
+
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	resp, latency := route.next()
+
+	if wait := latency + resp.Delay; wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	for k, v := range resp.Headers {
+		w.Header().Set(k, v)
+	}
+
+	w.WriteHeader(resp.Status)
+	_, _ = w.Write([]byte(resp.Body))
+}
+
+func (s *ScenarioServer) match(req *http.Request) *Route {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, r := range s.routes {
+		if (r.method == "" || r.method == req.Method) && r.path == req.URL.Path {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// Respond appends a response with the given status and body to the script.
+func (r *Route) Respond(status int, body string) *Route {
+	return r.RespondWith(ScriptedResponse{Status: status, Body: body})
+}
+
+// RespondWith appends a response to the script.
+// A zero status is served as 200 OK.
+func (r *Route) RespondWith(resp ScriptedResponse) *Route {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if resp.Status == 0 {
+		resp.Status = http.StatusOK
+	}
+
+	r.responses = append(r.responses, resp)
+
+	return r
+}
+
+// WithLatency delays every response of the route by d.
+func (r *Route) WithLatency(d time.Duration) *Route {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.latency = d
+
+	return r
+}
+
+// Calls returns the number of requests served by the route.
+func (r *Route) Calls() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.calls
+}
+
+// AssertCalled checks that the route has served exactly the given number of requests.
+func (r *Route) AssertCalled(t *testing.T, times int) {
+	t.Helper()
+
+	if calls := r.Calls(); calls != times {
+		t.Errorf("Expected %s %s to be called %d times, but got %d", r.method, r.path, times, calls)
+	}
+}
+
+// next returns the scripted response for the current call.
+// The last response is repeated once the script is exhausted.
+func (r *Route) next() (ScriptedResponse, time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.calls++
+
+	if len(r.responses) == 0 {
+		return ScriptedResponse{Status: http.StatusOK}, r.latency
+	}
+
+	idx := r.calls - 1
+	if idx >= len(r.responses) {
+		idx = len(r.responses) - 1
+	}
+
+	return r.responses[idx], r.latency
+}
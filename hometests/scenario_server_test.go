@@ -0,0 +1,71 @@
+package hometests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioServer(t *testing.T) {
+	t.Parallel()
+
+	s := NewScenarioServer(t)
+
+	users := s.On(http.MethodGet, "/users").
+		Respond(http.StatusInternalServerError, "").
+		RespondWith(ScriptedResponse{
+			Status:  http.StatusOK,
+			Body:    `{"id":1}`,
+			Headers: map[string]string{"Content-Type": "application/json"},
+		})
+	anyMethod := s.On("", "/any")
+
+	for _, want := range []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK} {
+		resp := doRequest(t, http.MethodGet, s.URL+"/users")
+		assert.Equal(t, want, resp.StatusCode)
+	}
+
+	resp := doRequest(t, http.MethodGet, s.URL+"/users")
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"id":1}`, string(body))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	assert.Equal(t, http.StatusNotFound, doRequest(t, http.MethodPost, s.URL+"/users").StatusCode)
+	assert.Equal(t, http.StatusOK, doRequest(t, http.MethodDelete, s.URL+"/any").StatusCode)
+
+	users.AssertCalled(t, 4)
+	anyMethod.AssertCalled(t, 1)
+	assert.Len(t, s.Unmatched(), 1)
+}
+
+func TestScenarioServer_Latency(t *testing.T) {
+	t.Parallel()
+
+	s := NewScenarioServer(t)
+	s.On(http.MethodGet, "/slow").WithLatency(50*time.Millisecond).Respond(http.StatusOK, "")
+
+	start := time.Now()
+	resp := doRequest(t, http.MethodGet, s.URL+"/slow")
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func doRequest(t *testing.T, method, url string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
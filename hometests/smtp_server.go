@@ -0,0 +1,189 @@
+package hometests
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// EmailMessage is a message received by SMTPServer.
+type EmailMessage struct {
+	Header  mail.Header
+	From    string
+	Subject string
+	Body    string
+	To      []string
+	Raw     []byte
+}
+
+// MailBox holds the messages received by SMTPServer.
+type MailBox struct {
+	messages []EmailMessage
+	changed  chan struct{}
+
+	mutex sync.Mutex
+}
+
+// SMTPServer starts a plain SMTP server on a local port that accepts every message and stores it in the mailbox.
+// It supports HELO/EHLO, MAIL, RCPT, DATA, RSET, NOOP and QUIT without authentication and TLS,
+// so it works with net/smtp.SendMail and most mail clients configured for a local relay.
+// The server is closed automatically when the test finishes.
+func SMTPServer(t *testing.T) (string, *MailBox) {
+	t.Helper()
+
+	box := &MailBox{changed: make(chan struct{})}
+	addr, _ := TCPServer(t, box.serve)
+
+	return addr, box
+}
+
+// Messages returns a copy of all received messages.
+func (b *MailBox) Messages() []EmailMessage {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return append([]EmailMessage(nil), b.messages...)
+}
+
+// Count returns the number of received messages.
+func (b *MailBox) Count() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return len(b.messages)
+}
+
+// WaitForMessages blocks until at least n messages are received and returns all of them.
+// The test fails if it doesn't happen within the timeout.
+func (b *MailBox) WaitForMessages(t *testing.T, n int, timeout time.Duration) []EmailMessage {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		b.mutex.Lock()
+		messages, changed := append([]EmailMessage(nil), b.messages...), b.changed
+		b.mutex.Unlock()
+
+		if len(messages) >= n {
+			return messages
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			t.Fatalf("Expected %d messages within %s, but got %d", n, timeout, len(messages))
+		}
+	}
+}
+
+func (b *MailBox) serve(conn net.Conn) {
+	tp := textproto.NewConn(conn)
+	defer tp.Close()
+
+	var (
+		from string
+		to   []string
+	)
+
+	_ = tp.PrintfLine("220 localhost ESMTP hometests")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "HELO", "EHLO":
+			_ = tp.PrintfLine("250 localhost")
+		case "MAIL":
+			from, to = smtpAddress(arg), nil
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			to = append(to, smtpAddress(arg))
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			if len(to) == 0 {
+				_ = tp.PrintfLine("503 no recipients")
+
+				continue
+			}
+
+			_ = tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+
+			raw, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			b.add(parseEmail(from, to, raw))
+
+			from, to = "", nil
+			_ = tp.PrintfLine("250 OK")
+		case "RSET":
+			from, to = "", nil
+			_ = tp.PrintfLine("250 OK")
+		case "NOOP":
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+
+			return
+		default:
+			_ = tp.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+func (b *MailBox) add(msg EmailMessage) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.messages = append(b.messages, msg)
+
+	// wake up all waiters
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// smtpAddress extracts the address from arguments like "FROM:<a@b.c> SIZE=10".
+func smtpAddress(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+
+	return strings.Trim(addr, "<>")
+}
+
+// parseEmail parses the message; if it is malformed, only the envelope and the raw data are set.
+func parseEmail(from string, to []string, raw []byte) EmailMessage {
+	msg := EmailMessage{From: from, To: to, Raw: raw}
+
+	parsed, err := mail.ReadMessage(bufio.NewReader(bytes.NewReader(raw)))
+	if err != nil {
+		return msg
+	}
+
+	body, _ := io.ReadAll(parsed.Body)
+
+	msg.Header = parsed.Header
+	msg.Body = string(body)
+
+	msg.Subject = parsed.Header.Get("Subject")
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(msg.Subject); err == nil {
+		msg.Subject = decoded
+	}
+
+	return msg
+}
@@ -0,0 +1,46 @@
+package hometests
+
+import (
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPServer(t *testing.T) {
+	t.Parallel()
+
+	addr, box := SMTPServer(t)
+
+	msg := "From: Alerts <alerts@example.com>\r\n" +
+		"To: ops@example.com\r\n" +
+		"Subject: =?UTF-8?Q?Disk_usage_=E2=80=94_90%?=\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"Disk usage is high.\r\n" +
+		".leading dot\r\n"
+
+	err := smtp.SendMail(addr, nil, "alerts@example.com", []string{"ops@example.com", "oncall@example.com"}, []byte(msg))
+	require.NoError(t, err)
+
+	messages := box.WaitForMessages(t, 1, time.Second)
+	require.Len(t, messages, 1)
+
+	got := messages[0]
+	assert.Equal(t, "alerts@example.com", got.From)
+	assert.Equal(t, []string{"ops@example.com", "oncall@example.com"}, got.To)
+	assert.Equal(t, "Disk usage — 90%", got.Subject)
+	assert.Equal(t, "text/plain", got.Header.Get("Content-Type"))
+	assert.Equal(t, "Disk usage is high.\n.leading dot\n", got.Body)
+	assert.Equal(t, 1, box.Count())
+}
+
+func TestSMTPAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a@b.c", smtpAddress("FROM:<a@b.c> SIZE=100"))
+	assert.Equal(t, "a@b.c", smtpAddress("TO: <a@b.c>"))
+	assert.Equal(t, "", smtpAddress("FROM:<>"))
+}
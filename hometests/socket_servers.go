@@ -0,0 +1,184 @@
+package hometests
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+)
+
+const udpPacketSize = 64 * 1024
+
+// PayloadLog is a thread-safe log of payloads received by a test server.
+type PayloadLog struct {
+	payloads [][]byte
+
+	mutex sync.Mutex
+}
+
+// Payloads returns a copy of all received payloads.
+// For TCP servers every payload is a chunk of data returned by a single read.
+func (l *PayloadLog) Payloads() [][]byte {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return append([][]byte(nil), l.payloads...)
+}
+
+// Bytes returns all received payloads concatenated.
+func (l *PayloadLog) Bytes() []byte {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var all []byte
+	for _, p := range l.payloads {
+		all = append(all, p...)
+	}
+
+	return all
+}
+
+// Count returns the number of received payloads.
+func (l *PayloadLog) Count() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.payloads)
+}
+
+func (l *PayloadLog) add(p []byte) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.payloads = append(l.payloads, append([]byte(nil), p...))
+}
+
+// TCPServer starts a TCP server on a local port that serves every connection with the handler.
+// Everything read from the connections is recorded in the returned log.
+// If the handler is nil, the server echoes the received data back.
+// The server and all its connections are closed automatically when the test finishes.
+func TCPServer(t *testing.T, handler func(conn net.Conn)) (string, *PayloadLog) {
+	t.Helper()
+
+	if handler == nil {
+		handler = func(conn net.Conn) {
+			_, _ = io.Copy(conn, conn)
+		}
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		log   = &PayloadLog{}
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		conns = map[net.Conn]struct{}{}
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			mutex.Lock()
+			conns[conn] = struct{}{}
+			mutex.Unlock()
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				defer func() {
+					mutex.Lock()
+					delete(conns, conn)
+					mutex.Unlock()
+
+					_ = conn.Close()
+				}()
+
+				handler(&capturingConn{Conn: conn, log: log})
+			}()
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = l.Close()
+
+		mutex.Lock()
+		for conn := range conns {
+			_ = conn.Close()
+		}
+		mutex.Unlock()
+
+		wg.Wait()
+	})
+
+	return l.Addr().String(), log
+}
+
+// UDPEcho starts a UDP server on a local port that sends every received packet back to its sender.
+// Every received packet is recorded in the returned log.
+// The server is closed automatically when the test finishes.
+func UDPEcho(t *testing.T) (string, *PayloadLog) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := &PayloadLog{}
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		buf := make([]byte, udpPacketSize)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+
+				continue
+			}
+
+			log.add(buf[:n])
+			_, _ = conn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		<-done
+	})
+
+	return conn.LocalAddr().String(), log
+}
+
+// capturingConn records everything read from the connection.
+type capturingConn struct {
+	net.Conn
+	log *PayloadLog
+}
+
+func (c *capturingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.log.add(p[:n])
+	}
+
+	return n, err
+}
@@ -0,0 +1,77 @@
+package hometests
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPServer_Echo(t *testing.T) {
+	t.Parallel()
+
+	addr, log := TCPServer(t, nil)
+
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("ping\n"))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+
+	assert.Equal(t, "ping\n", line)
+	assert.Equal(t, "ping\n", string(log.Bytes()))
+}
+
+func TestTCPServer_Handler(t *testing.T) {
+	t.Parallel()
+
+	addr, log := TCPServer(t, func(conn net.Conn) {
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		_, _ = conn.Write([]byte("got " + line))
+	})
+
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+
+	assert.Equal(t, "got hello\n", line)
+	assert.Equal(t, 1, log.Count())
+}
+
+func TestUDPEcho(t *testing.T) {
+	t.Parallel()
+
+	addr, log := UDPEcho(t)
+
+	conn, err := net.DialTimeout("udp", addr, time.Second)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("packet"))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, "packet", string(buf[:n]))
+	assert.Equal(t, [][]byte{[]byte("packet")}, log.Payloads())
+}
@@ -0,0 +1,117 @@
+package hometests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// SSEEvent is a single server-sent event.
+type SSEEvent struct {
+	ID    string
+	Event string
+	Data  string
+	// Delay is the time to wait before the event is sent.
+	Delay time.Duration
+}
+
+// StreamingServer starts a test server that writes the chunks with a chunked response,
+// flushing after every chunk and waiting interval between them.
+// The server is closed automatically when the test finishes.
+func StreamingServer(t *testing.T, chunks []string, interval time.Duration) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		for i, chunk := range chunks {
+			if i > 0 && !sleepContext(req, interval) {
+				return
+			}
+
+			_, _ = w.Write([]byte(chunk))
+			flusher.Flush()
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// SSEServer starts a test server that sends the events as a text/event-stream response,
+// flushing after every event.
+// The server is closed automatically when the test finishes.
+func SSEServer(t *testing.T, events []SSEEvent) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for _, event := range events {
+			if !sleepContext(req, event.Delay) {
+				return
+			}
+
+			_, _ = w.Write([]byte(event.String()))
+			flusher.Flush()
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// String formats the event in the text/event-stream wire format.
+func (e SSEEvent) String() string {
+	var sb strings.Builder
+
+	if e.ID != "" {
+		_, _ = fmt.Fprintf(&sb, "id: %s\n", e.ID)
+	}
+
+	if e.Event != "" {
+		_, _ = fmt.Fprintf(&sb, "event: %s\n", e.Event)
+	}
+
+	for _, line := range strings.Split(e.Data, "\n") {
+		_, _ = fmt.Fprintf(&sb, "data: %s\n", line)
+	}
+
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// sleepContext waits for d, returns false if the request is canceled first.
+func sleepContext(req *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	select {
+	case <-time.After(d):
+		return true
+	case <-req.Context().Done():
+		return false
+	}
+}
@@ -0,0 +1,46 @@
+package hometests
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingServer(t *testing.T) {
+	t.Parallel()
+
+	s := StreamingServer(t, []string{"one,", "two,", "three"}, 20*time.Millisecond)
+
+	resp := doRequest(t, http.MethodGet, s.URL)
+	assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+
+	buf := make([]byte, 64)
+
+	n, err := resp.Body.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "one,", string(buf[:n]))
+
+	rest, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "two,three", string(rest))
+}
+
+func TestSSEServer(t *testing.T) {
+	t.Parallel()
+
+	s := SSEServer(t, []SSEEvent{
+		{ID: "1", Event: "update", Data: "first"},
+		{Data: "multi\nline", Delay: 10 * time.Millisecond},
+	})
+
+	resp := doRequest(t, http.MethodGet, s.URL)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "id: 1\nevent: update\ndata: first\n\ndata: multi\ndata: line\n\n", string(body))
+}
@@ -0,0 +1,84 @@
+package hometests
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// subprocessEnv marks the test process started by RunSubprocessTest, its value is the test name.
+const subprocessEnv = "HOMETESTS_SUBPROCESS"
+
+// SubprocessResult is the outcome of a test re-executed by RunSubprocessTest.
+// Stdout also contains the output of the testing package, e.g. PASS or FAIL lines.
+type SubprocessResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// InSubprocess reports whether the current test runs in a subprocess started by RunSubprocessTest.
+// The test must do its os.Exit or log.Fatal work only then.
+func InSubprocess(t *testing.T) bool {
+	t.Helper()
+
+	return os.Getenv(subprocessEnv) == t.Name()
+}
+
+// RunSubprocessTest re-executes the test binary running only the named test (t.Name() if empty)
+// with the given extra environment variables and returns its exit code and output.
+//
+//	func TestFatal(t *testing.T) {
+//		if hometests.InSubprocess(t) {
+//			logger.Fatal().Msg("boom")
+//			return
+//		}
+//
+//		res := hometests.RunSubprocessTest(t, "", nil)
+//		assert.Equal(t, 1, res.ExitCode)
+//	}
+func RunSubprocessTest(t *testing.T, name string, env map[string]string) SubprocessResult {
+	t.Helper()
+
+	if name == "" {
+		name = t.Name()
+	}
+
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+
+	//nolint:gosec // the binary is the test itself
+	cmd := exec.Command(os.Args[0], "-test.run="+strings.Join(parts, "/"), "-test.count=1")
+	cmd.Env = append(os.Environ(), subprocessEnv+"="+name)
+
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	var result SubprocessResult
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("failed to run subprocess test %s: %v", name, err)
+		}
+
+		result.ExitCode = exitErr.ExitCode()
+	}
+
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	return result
+}
@@ -0,0 +1,42 @@
+package hometests
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunSubprocessTest(t *testing.T) {
+	t.Parallel()
+
+	if InSubprocess(t) {
+		fmt.Println("stdout:", os.Getenv("SUBPROCESS_VALUE")) //nolint:forbidigo
+		fmt.Fprintln(os.Stderr, "stderr output")
+		os.Exit(3)
+	}
+
+	res := RunSubprocessTest(t, "", map[string]string{"SUBPROCESS_VALUE": "42"})
+
+	assert.Equal(t, 3, res.ExitCode)
+	assert.Contains(t, res.Stdout, "stdout: 42")
+	assert.Contains(t, res.Stderr, "stderr output")
+}
+
+func TestRunSubprocessTest_Subtest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passing subtest", func(t *testing.T) {
+		t.Parallel()
+
+		if InSubprocess(t) {
+			return
+		}
+
+		res := RunSubprocessTest(t, "", nil)
+
+		assert.Equal(t, 0, res.ExitCode)
+		assert.Contains(t, res.Stdout, "PASS")
+	})
+}
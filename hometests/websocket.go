@@ -0,0 +1,499 @@
+package hometests
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // required by the websocket handshake
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Websocket message types, the values are the opcodes of RFC 6455.
+const (
+	WSText   = 1
+	WSBinary = 2
+)
+
+const (
+	wsGUID           = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsMaxMessageSize = 16 << 20
+	wsDefaultTimeout = 5 * time.Second
+
+	wsOpContinuation = 0
+	wsOpClose        = 8
+	wsOpPing         = 9
+	wsOpPong         = 10
+)
+
+var errWSMessageTooLarge = errors.New("websocket message is too large")
+
+// WSConn is a minimal websocket connection (RFC 6455) without extensions and subprotocols.
+// Pings are answered and close frames are replied automatically while reading.
+// Reads and writes may run concurrently, but only one goroutine may read at a time.
+type WSConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	client bool
+
+	closeSent bool
+	mutex     sync.Mutex
+}
+
+// ReadMessage returns the next text or binary message.
+// io.EOF is returned after the peer closed the connection.
+func (c *WSConn) ReadMessage() (int, []byte, error) {
+	var (
+		msgType int
+		message []byte
+	)
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case wsOpPing:
+			if err = c.writeFrame(wsOpPong, payload); err != nil {
+				return 0, nil, err
+			}
+		case wsOpPong:
+		case wsOpClose:
+			_ = c.writeClose(payload)
+
+			return 0, nil, io.EOF
+		case wsOpContinuation, WSText, WSBinary:
+			if opcode != wsOpContinuation {
+				msgType, message = int(opcode), nil
+			}
+
+			if len(message)+len(payload) > wsMaxMessageSize {
+				return 0, nil, errWSMessageTooLarge
+			}
+
+			message = append(message, payload...)
+
+			if fin {
+				return msgType, message, nil
+			}
+		default:
+			return 0, nil, fmt.Errorf("unsupported websocket opcode %d", opcode)
+		}
+	}
+}
+
+// WriteMessage sends a text or binary message in a single frame.
+func (c *WSConn) WriteMessage(msgType int, data []byte) error {
+	return c.writeFrame(byte(msgType), data)
+}
+
+// Close sends a normal closure frame and closes the connection without waiting for the reply.
+func (c *WSConn) Close() error {
+	_ = c.writeClose(binary.BigEndian.AppendUint16(nil, 1000))
+
+	return c.conn.Close()
+}
+
+// SetDeadline sets the read and write deadline of the underlying connection.
+func (c *WSConn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+func (c *WSConn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin, opcode := header[0]&0x80 != 0, header[0]&0x0f
+	masked, size := header[1]&0x80 != 0, uint64(header[1]&0x7f)
+
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+
+		size = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+
+		size = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if size > wsMaxMessageSize {
+		return false, 0, nil, errWSMessageTooLarge
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (c *WSConn) writeClose(payload []byte) error {
+	c.mutex.Lock()
+	sent := c.closeSent
+	c.closeSent = true
+	c.mutex.Unlock()
+
+	if sent {
+		return nil
+	}
+
+	return c.writeFrame(wsOpClose, payload)
+}
+
+func (c *WSConn) writeFrame(opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode}
+
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+
+	switch size := len(payload); {
+	case size < 126:
+		frame = append(frame, maskBit|byte(size))
+	case size <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(size))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(size))
+	}
+
+	if c.client {
+		// clients must mask every frame with a random key
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+
+		frame = append(frame, mask[:]...)
+
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, err := c.conn.Write(frame)
+
+	return err
+}
+
+// WSServer starts a test server upgrading every request to a websocket connection served by the handler.
+// If the handler is nil, the server echoes the received messages back.
+// The connection is closed when the handler returns; all connections are closed when the test finishes.
+func WSServer(t *testing.T, handler func(conn *WSConn)) *httptest.Server {
+	t.Helper()
+
+	if handler == nil {
+		handler = func(conn *WSConn) {
+			for {
+				msgType, data, err := conn.ReadMessage()
+				if err != nil || conn.WriteMessage(msgType, data) != nil {
+					return
+				}
+			}
+		}
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		conns = map[*WSConn]struct{}{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wg.Add(1)
+		defer wg.Done()
+
+		conn, err := wsAccept(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mutex.Lock()
+		conns[conn] = struct{}{}
+		mutex.Unlock()
+
+		defer func() {
+			mutex.Lock()
+			delete(conns, conn)
+			mutex.Unlock()
+
+			_ = conn.Close()
+		}()
+
+		handler(conn)
+	}))
+
+	t.Cleanup(func() {
+		mutex.Lock()
+		for conn := range conns {
+			_ = conn.conn.Close()
+		}
+		mutex.Unlock()
+
+		wg.Wait()
+		server.Close()
+	})
+
+	return server
+}
+
+func wsAccept(w http.ResponseWriter, r *http.Request) (*WSConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		return nil, errors.New("not a websocket handshake")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("connection can't be hijacked")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\n"+
+		"Upgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", wsAcceptKey(key))
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return &WSConn{conn: conn, reader: rw.Reader}, nil
+}
+
+func wsAcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + wsGUID)) //nolint:gosec
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// WSDial opens a websocket connection to the ws:// URL, http:// URLs of test servers are accepted as well.
+// TLS is not supported.
+func WSDial(rawURL string, timeout time.Duration) (*WSConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "ws" && u.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported websocket scheme %q", u.Scheme)
+	}
+
+	conn, err := net.DialTimeout("tcp", u.Host, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := wsHandshake(conn, u, timeout)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func wsHandshake(conn net.Conn, u *url.URL, timeout time.Duration) (*WSConn, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	_, err := fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n", u.RequestURI(), u.Host, key)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, &http.Request{Method: http.MethodGet})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("websocket handshake failed with status %d", resp.StatusCode)
+	}
+
+	if resp.Header.Get("Sec-WebSocket-Accept") != wsAcceptKey(key) {
+		return nil, errors.New("websocket handshake failed: invalid accept key")
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return &WSConn{conn: conn, reader: reader, client: true}, nil
+}
+
+// WSClient is a websocket test client with assertion helpers, every operation fails the test on timeout.
+type WSClient struct {
+	t       *testing.T
+	conn    *WSConn
+	timeout time.Duration
+}
+
+// NewWSClient connects to the websocket test server, see WSServer.
+// The connection is closed automatically when the test finishes.
+func NewWSClient(t *testing.T, rawURL string) *WSClient {
+	t.Helper()
+
+	conn, err := WSDial(rawURL, wsDefaultTimeout)
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", rawURL, err)
+	}
+
+	t.Cleanup(func() { _ = conn.conn.Close() })
+
+	return &WSClient{t: t, conn: conn, timeout: wsDefaultTimeout}
+}
+
+// WithTimeout sets the timeout of every operation, 5 seconds by default.
+func (c *WSClient) WithTimeout(timeout time.Duration) *WSClient {
+	c.timeout = timeout
+
+	return c
+}
+
+// Conn returns the underlying connection.
+func (c *WSClient) Conn() *WSConn {
+	return c.conn
+}
+
+// SendText sends a text message.
+func (c *WSClient) SendText(text string) {
+	c.t.Helper()
+
+	c.send(WSText, []byte(text))
+}
+
+// SendJSON sends v encoded as JSON in a text message.
+func (c *WSClient) SendJSON(v any) {
+	c.t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		c.t.Fatalf("failed to encode websocket message: %v", err)
+	}
+
+	c.send(WSText, data)
+}
+
+// Receive returns the next message.
+func (c *WSClient) Receive() (int, []byte) {
+	c.t.Helper()
+
+	_ = c.conn.conn.SetReadDeadline(time.Now().Add(c.timeout))
+
+	msgType, data, err := c.conn.ReadMessage()
+	if err != nil {
+		c.t.Fatalf("failed to receive websocket message: %v", err)
+	}
+
+	return msgType, data
+}
+
+// ExpectText checks that the next message is the given text.
+func (c *WSClient) ExpectText(want string) {
+	c.t.Helper()
+
+	if _, data := c.Receive(); string(data) != want {
+		c.t.Errorf("Expected websocket message %q, but got %q", want, data)
+	}
+}
+
+// ExpectJSON decodes the next message into v.
+func (c *WSClient) ExpectJSON(v any) {
+	c.t.Helper()
+
+	_, data := c.Receive()
+	if err := json.Unmarshal(data, v); err != nil {
+		c.t.Fatalf("failed to decode websocket message %q: %v", data, err)
+	}
+}
+
+// Close performs the closing handshake, waiting for the reply of the server within the timeout.
+func (c *WSClient) Close() {
+	c.t.Helper()
+
+	_ = c.conn.SetDeadline(time.Now().Add(c.timeout))
+
+	if err := c.conn.writeClose(binary.BigEndian.AppendUint16(nil, 1000)); err != nil {
+		c.t.Fatalf("failed to close websocket: %v", err)
+	}
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				c.t.Errorf("Expected the server to close the websocket, but got %v", err)
+			}
+
+			break
+		}
+	}
+
+	_ = c.conn.conn.Close()
+}
+
+func (c *WSClient) send(msgType int, data []byte) {
+	c.t.Helper()
+
+	_ = c.conn.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+
+	if err := c.conn.WriteMessage(msgType, data); err != nil {
+		c.t.Fatalf("failed to send websocket message: %v", err)
+	}
+}
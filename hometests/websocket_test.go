@@ -0,0 +1,81 @@
+package hometests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWSServer_Echo(t *testing.T) {
+	t.Parallel()
+
+	server := WSServer(t, nil)
+	client := NewWSClient(t, server.URL).WithTimeout(time.Second)
+
+	client.SendText("hello")
+	client.ExpectText("hello")
+
+	type event struct {
+		Type string `json:"type"`
+		ID   int    `json:"id"`
+	}
+
+	client.SendJSON(event{Type: "created", ID: 7})
+
+	var got event
+
+	client.ExpectJSON(&got)
+	assert.Equal(t, event{Type: "created", ID: 7}, got)
+
+	large := strings.Repeat("x", 70_000)
+	client.SendText(large)
+	client.ExpectText(large)
+
+	medium := strings.Repeat("y", 300)
+	require.NoError(t, client.Conn().WriteMessage(WSBinary, []byte(medium)))
+
+	msgType, data := client.Receive()
+	assert.Equal(t, WSBinary, msgType)
+	assert.Equal(t, medium, string(data))
+
+	client.Close()
+}
+
+func TestWSServer_ServerPush(t *testing.T) {
+	t.Parallel()
+
+	server := WSServer(t, func(conn *WSConn) {
+		// a fragmented message with a ping in between
+		_, _ = conn.conn.Write([]byte{0x01, 0x03, 'a', 'b', 'c'})
+		_, _ = conn.conn.Write([]byte{0x89, 0x00})
+		_, _ = conn.conn.Write([]byte{0x80, 0x02, 'd', 'e'})
+
+		_ = conn.WriteMessage(WSText, []byte("done"))
+
+		_, _, _ = conn.ReadMessage()
+	})
+
+	client := NewWSClient(t, server.URL)
+
+	client.ExpectText("abcde")
+	client.ExpectText("done")
+	client.Close()
+}
+
+func TestWSServer_NotWebsocket(t *testing.T) {
+	t.Parallel()
+
+	server := WSServer(t, nil)
+
+	resp, err := http.Get(server.URL) //nolint:noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	_, err = WSDial("https://"+strings.TrimPrefix(server.URL, "http://"), time.Second)
+	require.Error(t, err)
+}